@@ -0,0 +1,222 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Aggregations contains functions that aggregate the elements of an array.
+//
+//	sum, avg, median, stddev (population standard deviation), min and max of the numbers of an array
+//	percentile(array, p) with p from 0 to 100, interpolated linearly between the closest ranks
+//	count(array) and count(array, predicate)
+//	distinct(array) with the distinct elements in order of their first occurrence
+//
+// Arrays may be []interface{} or any other slice or array type.
+// A Lambda as last argument projects each element first, e.g. sum(orders, o => o.total).
+// Aggregations contains Lambdas.
+// sum and avg work on decimal.Decimal if an element is a decimal.Decimal,
+// median, percentile and stddev with float64 precision.
+func Aggregations() Language {
+	return aggregations
+}
+
+var aggregations = NewLanguage(
+	Lambdas(),
+	Function("sum", aggregateFunction("sum", 0,
+		func(x []float64) (float64, error) {
+			sum := 0.
+			for _, v := range x {
+				sum += v
+			}
+			return sum, nil
+		},
+		func(x []decimal.Decimal) (decimal.Decimal, error) {
+			return decimal.Sum(decimal.Zero, x...), nil
+		},
+	), aggregationInfo("sum returns the sum of the numbers in array.", `sum(orders, o => o.total)`)),
+	Function("avg", aggregateFunction("avg", 0,
+		func(x []float64) (float64, error) {
+			if len(x) == 0 {
+				return 0, fmt.Errorf("avg() of an empty array")
+			}
+			sum := 0.
+			for _, v := range x {
+				sum += v
+			}
+			return sum / float64(len(x)), nil
+		},
+		func(x []decimal.Decimal) (decimal.Decimal, error) {
+			return decimal.Avg(x[0], x[1:]...), nil
+		},
+	), aggregationInfo("avg returns the arithmetic mean of the numbers in array.", `avg([1, 2, 3])`)),
+	Function("median", aggregateFunction("median", 0,
+		func(x []float64) (float64, error) { return percentile("median", x, 50) }, nil,
+	), aggregationInfo("median returns the median of the numbers in array.", `median([3, 1, 2])`)),
+	Function("percentile", aggregateFunction("percentile", 1,
+		func(x []float64) (float64, error) { return percentile("percentile", x[1:], x[0]) }, nil,
+	), aggregationInfo("percentile returns the p-th percentile of the numbers in array.", `percentile(latencies, 95)`, "p")),
+	Function("stddev", aggregateFunction("stddev", 0,
+		func(x []float64) (float64, error) {
+			if len(x) == 0 {
+				return 0, fmt.Errorf("stddev() of an empty array")
+			}
+			mean := 0.
+			for _, v := range x {
+				mean += v
+			}
+			mean /= float64(len(x))
+			variance := 0.
+			for _, v := range x {
+				variance += (v - mean) * (v - mean)
+			}
+			return math.Sqrt(variance / float64(len(x))), nil
+		}, nil,
+	), aggregationInfo("stddev returns the population standard deviation of the numbers in array.", `stddev([2, 4, 4, 4, 5, 5, 7, 9])`)),
+	Function("count", count, aggregationInfo("count returns the number of elements in array for which predicate is true.", `count(orders, o => o.total > 100)`)),
+	Function("distinct", distinct, aggregationInfo("distinct returns the distinct elements of array.", `distinct(tags)`)),
+	minFunction,
+	maxFunction,
+)
+
+func aggregationInfo(description, example string, parameters ...string) FunctionInfo {
+	info := functionInfo(description, example, append(append([]string{"array"}, parameters...), "projection")...)
+	info.Variadic = true
+	return info
+}
+
+// aggregateFunction returns a function of an array, extra number arguments and an optional projection.
+// The extra arguments are passed in front of the numbers of the array.
+// If a number is a decimal.Decimal the result is computed by d or, if d is nil, by f with float64 precision.
+func aggregateFunction(name string, extra int, f func([]float64) (float64, error), d func([]decimal.Decimal) (decimal.Decimal, error)) func(context.Context, ...interface{}) (interface{}, error) {
+	return func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		values, err := aggregated(c, name, arguments, extra)
+		if err != nil {
+			return nil, err
+		}
+		values = append(append([]interface{}{}, arguments[1:1+extra]...), values...)
+		// computeNumbers takes functions without error
+		var computeErr error
+		fd := func(x []float64) float64 {
+			r, err := f(x)
+			computeErr = err
+			return r
+		}
+		var dd func([]decimal.Decimal) decimal.Decimal
+		if d != nil {
+			dd = func(x []decimal.Decimal) decimal.Decimal {
+				r, err := d(x[extra:])
+				computeErr = err
+				return r
+			}
+		}
+		r, err := computeNumbers(c, name, values, fd, dd)
+		if computeErr != nil {
+			return nil, computeErr
+		}
+		return r, err
+	}
+}
+
+// aggregated returns the elements of the array in arguments[0], projected by a Lambda in the last argument.
+// extra is the number of arguments between the array and the Lambda.
+func aggregated(c context.Context, name string, arguments []interface{}, extra int) ([]interface{}, error) {
+	var projection Lambda
+	if len(arguments) == extra+2 {
+		l, ok := arguments[extra+1].(Lambda)
+		if !ok {
+			return nil, fmt.Errorf("%s() expects a lambda as last argument but got %T", name, arguments[extra+1])
+		}
+		projection = l
+	} else if len(arguments) != extra+1 {
+		return nil, fmt.Errorf("%s() expects %d to %d arguments but got %d", name, extra+1, extra+2, len(arguments))
+	}
+	values, ok := arrayElements(arguments[0])
+	if !ok {
+		return nil, fmt.Errorf("%s() expects an array but got %T", name, arguments[0])
+	}
+	if projection == nil {
+		return values, nil
+	}
+	projected := make([]interface{}, len(values))
+	for i, v := range values {
+		p, err := projection(c, v)
+		if err != nil {
+			return nil, err
+		}
+		projected[i] = p
+	}
+	return projected, nil
+}
+
+// arrayElements returns the elements of an array or slice.
+func arrayElements(array interface{}) ([]interface{}, bool) {
+	switch array := array.(type) {
+	case []interface{}:
+		return array, true
+	case nil, string, []byte:
+		return nil, false
+	}
+	v := reflect.ValueOf(array)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
+
+// percentile returns the p-th percentile of x interpolated linearly between the closest ranks.
+func percentile(name string, x []float64, p float64) (float64, error) {
+	if len(x) == 0 {
+		return 0, fmt.Errorf("%s() of an empty array", name)
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("%s() expects a percentile from 0 to 100 but got %v", name, p)
+	}
+	sorted := append([]float64{}, x...)
+	sort.Float64s(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(sorted)-1 {
+		return sorted[lower], nil
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower]), nil
+}
+
+func count(c context.Context, arguments ...interface{}) (interface{}, error) {
+	values, err := aggregated(c, "count", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(arguments) == 1 {
+		return float64(len(values)), nil
+	}
+	conv := contextConversion(c)
+	n := 0.
+	for _, v := range values {
+		b, ok := conv.boolean(v)
+		if !ok {
+			return nil, fmt.Errorf("count() expects a predicate returning bool but got %T", v)
+		}
+		if b {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func distinct(c context.Context, arguments ...interface{}) (interface{}, error) {
+	values, err := aggregated(c, "distinct", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return unique(values), nil
+}
@@ -0,0 +1,95 @@
+package gval
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+type order struct {
+	Total float64
+	Items []int
+}
+
+func TestAggregations(t *testing.T) {
+	orders := map[string]interface{}{
+		"orders": []order{{Total: 10, Items: []int{1, 2}}, {Total: 30, Items: []int{3}}, {Total: 20}},
+		"values": []interface{}{1, 2., int32(3), 4},
+	}
+	tests := []evaluationTest{
+		{name: "sum", expression: `sum([1, 2, 3])`, want: 6.},
+		{name: "sum empty", expression: `sum([])`, want: 0.},
+		{name: "sum parameter", expression: `sum(values)`, parameter: orders, want: 10.},
+		{name: "sum projection", expression: `sum(orders, o => o.Total)`, parameter: orders, want: 60.},
+		{name: "sum nested projection", expression: `sum(orders, o => sum(o.Items, i => i * o.Total))`, parameter: orders, want: 120.},
+		{name: "sum no array", expression: `sum(1)`, wantErr: "sum() expects an array but got float64"},
+		{name: "sum no lambda", expression: `sum([1], 2)`, wantErr: "sum() expects a lambda as last argument but got float64"},
+		{name: "sum no number", expression: `sum(["a"])`, wantErr: "sum() expects numbers but got string"},
+		{name: "avg", expression: `avg(orders, o => o.Total)`, parameter: orders, want: 20.},
+		{name: "avg empty", expression: `avg([])`, wantErr: "avg() of an empty array"},
+		{name: "median odd", expression: `median([3, 1, 2])`, want: 2.},
+		{name: "median even", expression: `median([4, 1, 3, 2])`, want: 2.5},
+		{name: "percentile", expression: `percentile([1, 2, 3, 4, 5], 90)`, want: 4.6},
+		{name: "percentile projection", expression: `percentile(orders, 100, o => o.Total)`, parameter: orders, want: 30.},
+		{name: "percentile out of range", expression: `percentile([1], 101)`, wantErr: "percentile() expects a percentile from 0 to 100 but got 101"},
+		{name: "stddev", expression: `stddev([2, 4, 4, 4, 5, 5, 7, 9])`, want: 2.},
+		{name: "count", expression: `count(orders)`, parameter: orders, want: 3.},
+		{name: "count predicate", expression: `count(orders, o => o.Total >= 20)`, parameter: orders, want: 2.},
+		{name: "distinct", expression: `distinct(["a", "b", "a", 1, 1])`, want: []interface{}{"a", "b", 1.}},
+		{name: "distinct projection", expression: `distinct(orders, o => len(o.Items) > 0)`, parameter: orders, want: []interface{}{true, false}},
+		{name: "min projection", expression: `min(orders, o => o.Total)`, parameter: orders, want: 10.},
+		{name: "max", expression: `max(values)`, parameter: orders, want: 4.},
+		{name: "max numbers", expression: `max(1, 3, 2)`, want: 3.},
+		{name: "lambda shadows parameter", expression: `sum(values, values => values * 2)`, parameter: orders, want: 20.},
+		{name: "lambda parameter out of scope", expression: `sum([1], x => x) + x`, parameter: map[string]interface{}{"x": 1}, want: 2.},
+	}
+	for i := range tests {
+		tests[i].extension = NewLanguage(Aggregations(), Function("len", func(a []int) int { return len(a) }))
+	}
+	tests = append(tests,
+		evaluationTest{
+			name:       "lambda in script",
+			expression: "let f = 3; sum([1, 2], x => x * f)",
+			extension:  NewLanguage(Script(), Aggregations()),
+			want:       9.,
+		},
+		evaluationTest{
+			name:       "decimal",
+			expression: `sum([0.1, 0.2]) == 0.3 && avg([0.1, 0.2]) == 0.15`,
+			extension:  NewLanguage(Aggregations(), DecimalArithmetic()),
+			want:       true,
+		},
+		evaluationTest{
+			name:       "decimal median",
+			expression: `median(x)`,
+			extension:  NewLanguage(Aggregations(), DecimalArithmetic()),
+			parameter:  map[string]interface{}{"x": []decimal.Decimal{decimal.New(1, 0), decimal.New(2, 0)}},
+			want:       decimal.RequireFromString("1.5"),
+			equalityFunc: func(x, y interface{}) bool {
+				return x.(decimal.Decimal).Equal(y.(decimal.Decimal))
+			},
+		},
+		evaluationTest{
+			name:       "with math",
+			expression: `min(orders, o => o.Total) + max(1, 2)`,
+			extension:  NewLanguage(Math(), Aggregations()),
+			parameter:  orders,
+			want:       12.,
+		},
+		evaluationTest{
+			name:       "lambdas with math",
+			expression: `max(orders, o => o.Total)`,
+			extension:  NewLanguage(Math(), Lambdas()),
+			parameter:  orders,
+			want:       30.,
+		},
+		evaluationTest{
+			name:       "lambda without Lambdas",
+			expression: `max(orders, o => o.Total)`,
+			extension:  Math(),
+			parameter:  orders,
+			wantErr:    unexpected(`"="`, "arguments"),
+		},
+	)
+	testEvaluate(tests, t)
+}
@@ -0,0 +1,227 @@
+package gval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp/syntax"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Budget limits the evaluation of expressions. Zero values are unlimited.
+type Budget struct {
+	// MaxOperations limits the number of evaluated operators, function calls,
+	// variable selections and other expression parts.
+	// A function call additionally counts the Cost of its FunctionInfo.
+	MaxOperations uint64
+	// MaxStringLength limits the length in bytes of strings returned by expression parts.
+	MaxStringLength int
+	// MaxCollectionSize limits the length of arrays, slices and maps returned by expression parts.
+	MaxCollectionSize int
+	// MaxRegexSize limits the number of instructions of compiled regular expressions.
+	MaxRegexSize int
+	// MaxDuration limits the wall time of an evaluation.
+	// The context of functions is canceled after MaxDuration.
+	MaxDuration time.Duration
+}
+
+// BudgetExceeded is returned if an evaluation exceeds its Budget.
+type BudgetExceeded struct {
+	// Limit is the exceeded limit: operations, string length, collection size, regex size or duration.
+	Limit string
+	// Max is the maximum of the limit. The maximum duration is given in nanoseconds.
+	Max int64
+}
+
+func (e *BudgetExceeded) Error() string {
+	if e.Limit == "duration" {
+		return fmt.Sprintf("budget exceeded: max %s %s", e.Limit, time.Duration(e.Max))
+	}
+	return fmt.Sprintf("budget exceeded: max %s %d", e.Limit, e.Max)
+}
+
+// EvaluationBudget returns a Language that limits every evaluation by the budget.
+// Constant parts of expressions are checked while parsing.
+func EvaluationBudget(budget Budget) Language {
+	l := newLanguage()
+	l.budget = &budget
+	return l
+}
+
+type meterKey struct{}
+
+// meter measures the evaluation of an expression.
+type meter struct {
+	Budget
+	operations uint64
+	deadline   time.Time
+}
+
+func meterOf(c context.Context) *meter {
+	if c == nil {
+		return nil
+	}
+	m, _ := c.Value(meterKey{}).(*meter)
+	return m
+}
+
+// evaluable returns an Evaluable that measures the evaluation of eval.
+func (b Budget) evaluable(eval Evaluable) Evaluable {
+	if eval.IsConst() {
+		return eval
+	}
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		if meterOf(c) != nil {
+			return eval(c, v)
+		}
+		if c == nil {
+			c = context.Background()
+		}
+		m := &meter{Budget: b}
+		if b.MaxDuration > 0 {
+			m.deadline = time.Now().Add(b.MaxDuration)
+			var cancel context.CancelFunc
+			c, cancel = context.WithDeadline(c, m.deadline)
+			defer cancel()
+		}
+		r, err := eval(context.WithValue(c, meterKey{}, m), v)
+		if err != nil && b.MaxDuration > 0 && errors.Is(err, context.DeadlineExceeded) && !time.Now().Before(m.deadline) {
+			return nil, &BudgetExceeded{Limit: "duration", Max: int64(b.MaxDuration)}
+		}
+		return r, err
+	}
+}
+
+// operate counts n operations.
+func (m *meter) operate(n uint64) error {
+	if m.MaxOperations > 0 && atomic.AddUint64(&m.operations, n) > m.MaxOperations {
+		return &BudgetExceeded{Limit: "operations", Max: int64(m.MaxOperations)}
+	}
+	if m.MaxDuration > 0 && !time.Now().Before(m.deadline) {
+		return &BudgetExceeded{Limit: "duration", Max: int64(m.MaxDuration)}
+	}
+	return nil
+}
+
+// checkSize checks the size of a string or collection.
+func (b Budget) checkSize(v interface{}) error {
+	if b.MaxStringLength <= 0 && b.MaxCollectionSize <= 0 {
+		return nil
+	}
+	switch v := v.(type) {
+	case nil, bool, float64:
+		return nil
+	case string:
+		if b.MaxStringLength > 0 && len(v) > b.MaxStringLength {
+			return &BudgetExceeded{Limit: "string length", Max: int64(b.MaxStringLength)}
+		}
+		return nil
+	}
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.String:
+		return b.checkSize(value.String())
+	case reflect.Array, reflect.Slice, reflect.Map:
+		if b.MaxCollectionSize > 0 && value.Len() > b.MaxCollectionSize {
+			return &BudgetExceeded{Limit: "collection size", Max: int64(b.MaxCollectionSize)}
+		}
+	}
+	return nil
+}
+
+// chargeOperations counts n operations if the evaluation has a budget.
+func chargeOperations(c context.Context, n int) error {
+	if m := meterOf(c); m != nil && n > 0 {
+		return m.operate(uint64(n))
+	}
+	return nil
+}
+
+// checkStringLength checks the length of a string before it is built if the evaluation has a budget.
+func checkStringLength(c context.Context, length int) error {
+	if m := meterOf(c); m != nil && m.MaxStringLength > 0 && length > m.MaxStringLength {
+		return &BudgetExceeded{Limit: "string length", Max: int64(m.MaxStringLength)}
+	}
+	return nil
+}
+
+// regexpSize returns the number of instructions of the compiled regular expression.
+func regexpSize(pattern string) int {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return 0
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0
+	}
+	return len(prog.Inst)
+}
+
+// lazyRegexpSize returns the size of the regular expression computed on first use.
+func lazyRegexpSize(pattern string) func() int {
+	var once sync.Once
+	size := 0
+	return func() int {
+		once.Do(func() { size = regexpSize(pattern) })
+		return size
+	}
+}
+
+// checkRegexpSize checks the size of a regular expression if the evaluation has a budget.
+// size is only called if needed.
+func checkRegexpSize(c context.Context, size func() int) error {
+	if m := meterOf(c); m != nil && m.MaxRegexSize > 0 && size() > m.MaxRegexSize {
+		return &BudgetExceeded{Limit: "regex size", Max: int64(m.MaxRegexSize)}
+	}
+	return nil
+}
+
+// metered counts the evaluation of eval as operation and checks the size of its result.
+// The size of constants is checked immediately.
+func (p *Parser) metered(eval Evaluable) (Evaluable, error) {
+	if p.budget == nil {
+		return eval, nil
+	}
+	budget := *p.budget
+	if eval.IsConst() {
+		v, err := eval(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return eval, budget.checkSize(v)
+	}
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		m := meterOf(c)
+		if m == nil {
+			return eval(c, v)
+		}
+		if err := m.operate(1); err != nil {
+			return nil, err
+		}
+		r, err := eval(c, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := budget.checkSize(r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}, nil
+}
+
+// meteredBuilder meters the Evaluables of an infix operator.
+func (p *Parser) meteredBuilder(builder infixBuilder) infixBuilder {
+	if p.budget == nil {
+		return builder
+	}
+	return func(a, b Evaluable) (Evaluable, error) {
+		eval, err := builder(a, b)
+		if err != nil {
+			return nil, err
+		}
+		return p.metered(eval)
+	}
+}
@@ -0,0 +1,171 @@
+package gval
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluationBudget(t *testing.T) {
+	slow := Function("slow", func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return true, nil
+		}
+	})
+	expensive := Function("expensive", func() bool { return true }, FunctionInfo{Cost: 10})
+	nodes := make([]interface{}, 30)
+	for i := range nodes {
+		nodes[i] = map[string]interface{}{"name": i}
+	}
+	tests := []struct {
+		name       string
+		budget     Budget
+		extension  Language
+		expression string
+		parameter  interface{}
+		want       interface{}
+		wantLimit  string
+		wantParse  bool
+	}{
+		{
+			name:       "within budget",
+			budget:     Budget{MaxOperations: 10, MaxStringLength: 10, MaxCollectionSize: 3, MaxRegexSize: 100, MaxDuration: time.Second},
+			expression: `x + 1 > 2 && 1 in [1, 2, 3] && "abc" =~ "b"`,
+			parameter:  map[string]interface{}{"x": 2},
+			want:       true,
+		},
+		{
+			name:       "operations",
+			budget:     Budget{MaxOperations: 3},
+			expression: `x + x + x + x`,
+			parameter:  map[string]interface{}{"x": 2},
+			wantLimit:  "operations",
+		},
+		{
+			name:       "function cost",
+			budget:     Budget{MaxOperations: 5},
+			extension:  expensive,
+			expression: `expensive()`,
+			wantLimit:  "operations",
+		},
+		{
+			name:       "recursive descent",
+			budget:     Budget{MaxOperations: 20},
+			expression: `..id`,
+			parameter:  map[string]interface{}{"nodes": nodes},
+			wantLimit:  "operations",
+		},
+		{
+			name:       "string length",
+			budget:     Budget{MaxStringLength: 5},
+			expression: `x + x`,
+			parameter:  map[string]interface{}{"x": "abc"},
+			wantLimit:  "string length",
+		},
+		{
+			name:       "constant string length",
+			budget:     Budget{MaxStringLength: 5},
+			expression: `"abc" + "abc"`,
+			wantLimit:  "string length",
+			wantParse:  true,
+		},
+		{
+			name:       "collection size",
+			budget:     Budget{MaxCollectionSize: 2},
+			expression: `[x, x, x]`,
+			parameter:  map[string]interface{}{"x": 1},
+			wantLimit:  "collection size",
+		},
+		{
+			name:       "selected collection size",
+			budget:     Budget{MaxCollectionSize: 2},
+			expression: `x`,
+			parameter:  map[string]interface{}{"x": []int{1, 2, 3}},
+			wantLimit:  "collection size",
+		},
+		{
+			name:       "constant regex size",
+			budget:     Budget{MaxRegexSize: 10},
+			expression: `x =~ "abcdefghijkl[0-9]{3}"`,
+			parameter:  map[string]interface{}{"x": "a123"},
+			wantLimit:  "regex size",
+		},
+		{
+			name:       "regex size",
+			budget:     Budget{MaxRegexSize: 10},
+			expression: `"a123" !~ x`,
+			parameter:  map[string]interface{}{"x": "abcdefghijkl[0-9]{3}"},
+			wantLimit:  "regex size",
+		},
+		{
+			name:       "like pattern size",
+			budget:     Budget{MaxRegexSize: 10},
+			extension:  SQLOperators(),
+			expression: `x like "abc%def%ghi%"`,
+			parameter:  map[string]interface{}{"x": "abc"},
+			wantLimit:  "regex size",
+		},
+		{
+			name:       "duration",
+			budget:     Budget{MaxDuration: 10 * time.Millisecond},
+			extension:  slow,
+			expression: `slow()`,
+			wantLimit:  "duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := NewLanguage(Full(), SQLOperators(), tt.extension, EvaluationBudget(tt.budget))
+			eval, err := lang.NewEvaluable(tt.expression)
+			if err == nil {
+				var got interface{}
+				got, err = eval(context.Background(), tt.parameter)
+				if tt.wantParse {
+					t.Fatalf("NewEvaluable(%s) expected parsing error", tt.expression)
+				}
+				if tt.wantLimit == "" {
+					if err != nil || got != tt.want {
+						t.Fatalf("Evaluate(%s) = %v, %v want %v", tt.expression, got, err, tt.want)
+					}
+					return
+				}
+			}
+			var exceeded *BudgetExceeded
+			if !errors.As(err, &exceeded) {
+				t.Fatalf("Evaluate(%s) error = %v, want BudgetExceeded", tt.expression, err)
+			}
+			if exceeded.Limit != tt.wantLimit {
+				t.Errorf("Evaluate(%s) exceeded %s, want %s", tt.expression, exceeded.Limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestEvaluationBudget_perEvaluation(t *testing.T) {
+	eval, err := NewLanguage(Full(), EvaluationBudget(Budget{MaxOperations: 5})).NewEvaluable(`x + 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := eval(context.Background(), map[string]interface{}{"x": 1}); err != nil {
+			t.Fatalf("evaluation %d: %v", i, err)
+		}
+	}
+}
+
+func TestBudgetExceeded_Error(t *testing.T) {
+	for err, want := range map[error]string{
+		&BudgetExceeded{Limit: "operations", Max: 10}:                       "budget exceeded: max operations 10",
+		&BudgetExceeded{Limit: "duration", Max: int64(time.Second)}:         "budget exceeded: max duration 1s",
+		&BudgetExceeded{Limit: "string length", Max: int64(len("abcdefg"))}: "budget exceeded: max string length 7",
+	} {
+		if got := err.Error(); !strings.EqualFold(got, want) {
+			t.Errorf("Error() = %s, want %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/scanner"
+)
+
+// Case contains the SQL style conditional expression in two forms.
+// Keywords can be written in lower or upper case.
+//
+//	case when a then b when c then d else e end
+//	returns b if a is true, otherwise d if c is true, otherwise e
+//
+//	case x when a, b then c when d then e else f end
+//	returns c if x equals a or b, otherwise e if x equals d, otherwise f
+//
+// Only the selected branch is evaluated. Without else branch the result is nil
+// if no branch is selected. The second form compares with the == operator of the Language.
+func Case() Language {
+	return caseExpression
+}
+
+var caseExpression = NewLanguage(
+	prefixKeyword("case", parseCase),
+	prefixKeyword("CASE", parseCase),
+)
+
+func prefixKeyword(name string, ext extension) Language {
+	l := newLanguage()
+	l.prefixes[name] = ext
+	l.elements[name] = Element{Kind: PrefixExtensionElement, Name: name}
+	return l
+}
+
+type caseBranch struct {
+	conditions []Evaluable
+	result     Evaluable
+}
+
+func parseCase(c context.Context, p *Parser) (Evaluable, error) {
+	defer p.nest()()
+	var subject *binding
+	if !p.scanKeyword("when") {
+		p.Camouflage("case")
+		eval, err := p.ParseExpression(c)
+		if err != nil {
+			return nil, err
+		}
+		if !p.scanKeyword("when") {
+			return nil, p.Expected("case when", scanner.Ident)
+		}
+		subject = &binding{name: "case"}
+		return parseCaseBranches(c, p, subject, eval)
+	}
+	return parseCaseBranches(c, p, nil, nil)
+}
+
+func parseCaseBranches(c context.Context, p *Parser, subject *binding, subjectEval Evaluable) (Evaluable, error) {
+	equal, ok := p.infixBuilder("==")
+	if subject != nil && !ok {
+		return nil, fmt.Errorf("case with operand requires operator ==")
+	}
+
+	branches := []caseBranch{}
+	elseResult := p.Const(nil)
+	for {
+		branch := caseBranch{}
+		for {
+			condition, err := p.ParseExpression(c)
+			if err != nil {
+				return nil, err
+			}
+			if subject != nil {
+				condition, err = equal(subject.get, condition)
+				if err != nil {
+					return nil, err
+				}
+			}
+			branch.conditions = append(branch.conditions, condition)
+			if subject == nil || p.Scan() != ',' {
+				break
+			}
+		}
+		if subject != nil {
+			p.Camouflage("case when", ',')
+		}
+		if !p.scanKeyword("then") {
+			return nil, p.Expected("case then", scanner.Ident)
+		}
+		result, err := p.ParseExpression(c)
+		if err != nil {
+			return nil, err
+		}
+		branch.result = result
+		branches = append(branches, branch)
+
+		if p.scanKeyword("when") {
+			continue
+		}
+		if p.isKeyword("else") {
+			elseResult, err = p.ParseExpression(c)
+			if err != nil {
+				return nil, err
+			}
+			if !p.scanKeyword("end") {
+				return nil, p.Expected("case end", scanner.Ident)
+			}
+		} else if !p.isKeyword("end") {
+			return nil, p.Expected("case when, else or end", scanner.Ident)
+		}
+		break
+	}
+
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		cc := c
+		if subject != nil {
+			x, err := subjectEval(c, v)
+			if err != nil {
+				return nil, err
+			}
+			cc = subject.bind(c, x)
+		}
+		for _, branch := range branches {
+			for _, condition := range branch.conditions {
+				x, err := condition(cc, v)
+				if err != nil {
+					return nil, err
+				}
+				if valX := reflect.ValueOf(x); x != nil && !valX.IsZero() {
+					return branch.result(c, v)
+				}
+			}
+		}
+		return elseResult(c, v)
+	}, nil
+}
+
+// scanKeyword scans the next token and reports if it is the given keyword.
+// Keywords can be written in lower or upper case.
+func (p *Parser) scanKeyword(keyword string) bool {
+	p.Scan()
+	return p.isKeyword(keyword)
+}
+
+// isKeyword reports if the last scanned token is the given keyword.
+func (p *Parser) isKeyword(keyword string) bool {
+	if p.lastScan != scanner.Ident {
+		return false
+	}
+	text := p.TokenText()
+	return text == keyword || text == strings.ToUpper(keyword)
+}
+
+// infixBuilder returns the builder of the infix operator with given name.
+func (p *Parser) infixBuilder(name string) (infixBuilder, bool) {
+	switch op := p.operators[name].(type) {
+	case *infix:
+		return op.builder, true
+	case directInfix:
+		return op.infixBuilder, true
+	}
+	return nil, false
+}
@@ -0,0 +1,110 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestCase(t *testing.T) {
+	tiers := `case
+		when amount >= 1000 then "gold"
+		when amount >= 100 then "silver"
+		else "bronze"
+	end`
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "first branch",
+				expression: tiers,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"amount": 1500},
+				want:       "gold",
+			},
+			{
+				name:       "second branch",
+				expression: tiers,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"amount": 150},
+				want:       "silver",
+			},
+			{
+				name:       "else branch",
+				expression: tiers,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"amount": 15},
+				want:       "bronze",
+			},
+			{
+				name:       "no else",
+				expression: "case when false then 1 end",
+				extension:  Case(),
+				want:       nil,
+			},
+			{
+				name:       "upper case keywords",
+				expression: "CASE WHEN 1 > 2 THEN 1 ELSE 2 END",
+				extension:  Case(),
+				want:       2.,
+			},
+			{
+				name:       "mixed case keyword",
+				expression: "case When true then 1 end",
+				extension:  Case(),
+				wantErr:    unexpected("Ident", "case when"),
+			},
+			{
+				name:       "operand",
+				expression: `case severity when "critical", "high" then 1 when "low" then 3 else 2 end`,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"severity": "high"},
+				want:       1.,
+			},
+			{
+				name:       "operand uses language equality",
+				expression: `case x when 1 then "one" else "other" end`,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"x": 1},
+				want:       "one",
+			},
+			{
+				name:       "operand is evaluated once",
+				expression: `case foo.Nested.Dunk("a") when "b" then 1 when "adunk" then 2 end`,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"foo": foo},
+				want:       2.,
+			},
+			{
+				name:       "only selected branch is evaluated",
+				expression: `case when true then 1 else foo.AlwaysFail() end`,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"foo": foo},
+				want:       1.,
+			},
+			{
+				name:       "nested",
+				expression: `case when a then case when b then 1 else 2 end else 3 end + 10`,
+				extension:  Case(),
+				parameter:  map[string]interface{}{"a": true, "b": false},
+				want:       12.,
+			},
+			{
+				name:       "missing then",
+				expression: "case when true 1 end",
+				extension:  Case(),
+				wantErr:    unexpected("Int", "case then"),
+			},
+			{
+				name:       "missing end",
+				expression: "case when true then 1",
+				extension:  Case(),
+				wantErr:    unexpected("EOF", "case when, else or end"),
+			},
+			{
+				name:       "missing end after else",
+				expression: "case when true then 1 else 2",
+				extension:  Case(),
+				wantErr:    unexpected("EOF", "case end"),
+			},
+		},
+		t,
+	)
+}
@@ -0,0 +1,93 @@
+package gval
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestComments(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "line comment",
+				expression: "1 + 2 // three",
+				want:       3.,
+			},
+			{
+				name:       "block comment",
+				expression: "1 + /* two */ 2",
+				want:       3.,
+			},
+			{
+				name:       "multiline block comment",
+				expression: "/*\n * sum\n */\n1 + 2",
+				want:       3.,
+			},
+			{
+				name:       "comment between operator characters",
+				expression: "true &&/* and */true",
+				want:       true,
+			},
+			{
+				name:       "hash comment",
+				expression: "# sum\n1 + 2 # three",
+				extension:  HashComments(),
+				want:       3.,
+			},
+			{
+				name:       "hash without HashComments",
+				expression: "1 # three",
+				wantErr:    unexpected(`"#"`, "operator"),
+			},
+			{
+				name:       "comment in string",
+				expression: `"// no comment"`,
+				want:       "// no comment",
+			},
+			{
+				name:       "script line comments",
+				expression: "let a = 1 // one\n// two\na + 1 # result",
+				extension:  NewLanguage(Script(), HashComments()),
+				want:       2.,
+			},
+			{
+				name:       "script multiline block comment",
+				expression: "1 /* a\n b */ + 2",
+				extension:  Script(),
+				want:       3.,
+			},
+			{
+				name:       "script block comment on next line",
+				expression: "1\n/* a */ -2",
+				extension:  Script(),
+				want:       -2.,
+			},
+		},
+		t,
+	)
+}
+
+func TestParser_Comments(t *testing.T) {
+	var comments []Comment
+	l := NewLanguage(Full(), HashComments(), Init(func(c context.Context, p *Parser) (Evaluable, error) {
+		eval, err := p.ParseExpression(c)
+		comments = p.Comments()
+		return eval, err
+	}))
+	_, err := l.NewEvaluable("a /* b */ + c // d\n# e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, c := range comments {
+		got = append(got, c.Text)
+	}
+	want := []string{"/* b */", "// d", "# e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Comments() = %q, want %q", got, want)
+	}
+	if comments[1].Pos.Line != 1 || comments[2].Pos.Line != 2 {
+		t.Errorf("Comments() positions = %v, want lines 1 and 2", comments)
+	}
+}
@@ -0,0 +1,184 @@
+package gval
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+// NumberConverter returns a Language that converts values of custom types
+// to float64. E.g. a Money type, sql.NullFloat64 or json.Number.
+// It returns false if it can not convert the value.
+//
+// Converters are used by infix and prefix operators, by Evaluable.EvalInt,
+// Evaluable.EvalFloat64 and Evaluable.EvalBool and for arguments of functions.
+// They are tried before the builtin conversions. Converters of a later Language
+// in NewLanguage are tried first.
+// A NumberConverter is also used for decimal operands if there is no DecimalConverter
+// for the value.
+func NumberConverter(convert func(interface{}) (float64, bool)) Language {
+	return newLanguageConverter(conversion{number: convert})
+}
+
+// DecimalConverter returns a Language that converts values of custom types
+// to decimal.Decimal. See NumberConverter.
+func DecimalConverter(convert func(interface{}) (decimal.Decimal, bool)) Language {
+	return newLanguageConverter(conversion{decimal: convert})
+}
+
+// BoolConverter returns a Language that converts values of custom types
+// to bool. See NumberConverter.
+func BoolConverter(convert func(interface{}) (bool, bool)) Language {
+	return newLanguageConverter(conversion{boolean: convert})
+}
+
+// TextConverter returns a Language that converts values of custom types
+// to string. See NumberConverter.
+func TextConverter(convert func(interface{}) (string, bool)) Language {
+	return newLanguageConverter(conversion{text: convert})
+}
+
+func newLanguageConverter(c conversion) Language {
+	l := newLanguage()
+	l.converters = []conversion{c}
+	return l
+}
+
+// noConversion converts nothing.
+var noConversion = conversion{
+	text:    func(interface{}) (string, bool) { return "", false },
+	boolean: func(interface{}) (bool, bool) { return false, false },
+	number:  func(interface{}) (float64, bool) { return 0, false },
+	decimal: func(interface{}) (decimal.Decimal, bool) { return decimal.Zero, false },
+}
+
+// with returns a conversion that tries the converters before conv.
+func (conv conversion) with(converters []conversion) conversion {
+	for _, r := range converters {
+		if r.text != nil {
+			convert, fallback := r.text, conv.text
+			conv.text = func(o interface{}) (string, bool) {
+				if s, ok := convert(o); ok {
+					return s, true
+				}
+				return fallback(o)
+			}
+		}
+		if r.boolean != nil {
+			convert, fallback := r.boolean, conv.boolean
+			conv.boolean = func(o interface{}) (bool, bool) {
+				if b, ok := convert(o); ok {
+					return b, true
+				}
+				return fallback(o)
+			}
+		}
+		if r.number != nil {
+			convert, fallback := r.number, conv.number
+			conv.number = func(o interface{}) (float64, bool) {
+				if f, ok := convert(o); ok {
+					return f, true
+				}
+				return fallback(o)
+			}
+			if r.decimal == nil {
+				fallback := conv.decimal
+				conv.decimal = func(o interface{}) (decimal.Decimal, bool) {
+					if f, ok := convert(o); ok {
+						return decimal.NewFromFloat(f), true
+					}
+					return fallback(o)
+				}
+			}
+		}
+		if r.decimal != nil {
+			convert, fallback := r.decimal, conv.decimal
+			conv.decimal = func(o interface{}) (decimal.Decimal, bool) {
+				if d, ok := convert(o); ok {
+					return d, true
+				}
+				return fallback(o)
+			}
+		}
+	}
+	return conv
+}
+
+// contextConverters are the converters of a Language during parsing and evaluation.
+type contextConverters struct {
+	// operands converts operands of prefix operators and Evaluable.EvalX results
+	operands conversion
+	// arguments converts function arguments
+	arguments conversion
+}
+
+type convertersKey struct{}
+
+func newContextConverters(l Language) *contextConverters {
+	return &contextConverters{
+		operands:  l.conversion(true),
+		arguments: noConversion.with(l.converters),
+	}
+}
+
+// withConverters returns a context that contains the converters.
+func withConverters(c context.Context, converters *contextConverters) context.Context {
+	if c == nil {
+		c = context.Background()
+	}
+	return context.WithValue(c, convertersKey{}, converters)
+}
+
+func contextConvertersOf(c context.Context) *contextConverters {
+	if c != nil {
+		if converters, ok := c.Value(convertersKey{}).(*contextConverters); ok {
+			return converters
+		}
+	}
+	return nil
+}
+
+// contextConversion returns the conversion for operands in context c.
+func contextConversion(c context.Context) conversion {
+	if converters := contextConvertersOf(c); converters != nil {
+		return converters.operands
+	}
+	return typeConversion
+}
+
+// contextArgumentConversion returns the conversion for function arguments in context c.
+func contextArgumentConversion(c context.Context) conversion {
+	if converters := contextConvertersOf(c); converters != nil {
+		return converters.arguments
+	}
+	return noConversion
+}
+
+// convertersRequest is the parameter that makes a converting Evaluable return its converters.
+type convertersRequest struct{}
+
+// convertingEvaluable provides the converters to eval.
+// Called with convertersRequest it returns the converters without evaluating eval.
+//
+//go:noinline
+func convertingEvaluable(eval Evaluable, converters *contextConverters) Evaluable {
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		if _, ok := v.(convertersRequest); ok {
+			return converters, nil
+		}
+		return eval(withConverters(c, converters), v)
+	}
+}
+
+var convertingEvaluablePointer = reflect.ValueOf(convertingEvaluable(nil, nil)).Pointer()
+
+// resultConversion returns the conversion for the results of e.
+// These are the converters bound to e at parse time or the converters of context c.
+func (e Evaluable) resultConversion(c context.Context) conversion {
+	if reflect.ValueOf(e).Pointer() == convertingEvaluablePointer {
+		converters, _ := e(c, convertersRequest{})
+		return converters.(*contextConverters).operands
+	}
+	return contextConversion(c)
+}
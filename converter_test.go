@@ -0,0 +1,197 @@
+package gval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+type money struct {
+	cents int64
+}
+
+var moneyConverter = NewLanguage(
+	NumberConverter(func(o interface{}) (float64, bool) {
+		m, ok := o.(money)
+		return float64(m.cents) / 100, ok
+	}),
+	DecimalConverter(func(o interface{}) (decimal.Decimal, bool) {
+		m, ok := o.(money)
+		return decimal.New(m.cents, -2), ok
+	}),
+)
+
+var nullFloatConverter = NumberConverter(func(o interface{}) (float64, bool) {
+	n, ok := o.(sql.NullFloat64)
+	if !ok || !n.Valid {
+		return 0, false
+	}
+	return n.Float64, true
+})
+
+var jsonNumberConverter = NumberConverter(func(o interface{}) (float64, bool) {
+	n, ok := o.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+})
+
+type onOff string
+
+var onOffConverter = BoolConverter(func(o interface{}) (bool, bool) {
+	s, ok := o.(onOff)
+	if !ok || (s != "on" && s != "off") {
+		return false, false
+	}
+	return s == "on", true
+})
+
+func TestConverters(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "money arithmetic",
+				expression: `price * 2`,
+				extension:  NewLanguage(Full(), moneyConverter),
+				parameter:  map[string]interface{}{"price": money{150}},
+				want:       3.,
+			},
+			{
+				name:       "money without converter",
+				expression: `price * 2`,
+				parameter:  map[string]interface{}{"price": money{150}},
+				wantErr:    "invalid operation (gval.money) * (float64)",
+			},
+			{
+				name:       "money decimal arithmetic",
+				expression: `price + 0.1`,
+				extension:  NewLanguage(DecimalArithmetic(), moneyConverter),
+				parameter:  map[string]interface{}{"price": money{20}},
+				want:       decimal.RequireFromString("0.3"),
+				equalityFunc: func(x, y interface{}) bool {
+					return x.(decimal.Decimal).Equal(y.(decimal.Decimal))
+				},
+			},
+			{
+				name:       "number converter for decimal",
+				expression: `x + 1`,
+				extension:  NewLanguage(DecimalArithmetic(), jsonNumberConverter),
+				parameter:  map[string]interface{}{"x": json.Number("1.5")},
+				want:       decimal.RequireFromString("2.5"),
+				equalityFunc: func(x, y interface{}) bool {
+					return x.(decimal.Decimal).Equal(y.(decimal.Decimal))
+				},
+			},
+			{
+				name:       "json number comparison",
+				expression: `x > 2`,
+				extension:  NewLanguage(Full(), jsonNumberConverter),
+				parameter:  map[string]interface{}{"x": json.Number("2.5")},
+				want:       true,
+			},
+			{
+				name:       "valid sql null float",
+				expression: `x - 1`,
+				extension:  NewLanguage(Full(), nullFloatConverter),
+				parameter:  map[string]interface{}{"x": sql.NullFloat64{Float64: 3, Valid: true}},
+				want:       2.,
+			},
+			{
+				name:       "invalid sql null float",
+				expression: `x - 1`,
+				extension:  NewLanguage(Full(), nullFloatConverter),
+				parameter:  map[string]interface{}{"x": sql.NullFloat64{}},
+				wantErr:    "invalid operation (sql.NullFloat64) - (float64)",
+			},
+			{
+				name:       "prefix minus",
+				expression: `-price`,
+				extension:  NewLanguage(Full(), moneyConverter),
+				parameter:  map[string]interface{}{"price": money{150}},
+				want:       -1.5,
+			},
+			{
+				name:       "bool converter",
+				expression: `light && !switch`,
+				extension:  NewLanguage(Full(), onOffConverter),
+				parameter:  map[string]interface{}{"light": onOff("on"), "switch": onOff("off")},
+				want:       true,
+			},
+			{
+				name:       "text converter",
+				expression: `price + " EUR"`,
+				extension: NewLanguage(Full(), TextConverter(func(o interface{}) (string, bool) {
+					m, ok := o.(money)
+					return decimal.New(m.cents, -2).StringFixed(2), ok
+				})),
+				parameter: map[string]interface{}{"price": money{150}},
+				want:      "1.50 EUR",
+			},
+			{
+				name:       "later converter first",
+				expression: `x + 1`,
+				extension: NewLanguage(Full(), jsonNumberConverter, NumberConverter(func(o interface{}) (float64, bool) {
+					_, ok := o.(json.Number)
+					return 10, ok
+				})),
+				parameter: map[string]interface{}{"x": json.Number("1")},
+				want:      11.,
+			},
+			{
+				name:       "function argument",
+				expression: `half(price)`,
+				extension: NewLanguage(Full(), moneyConverter, Function("half", func(f float64) float64 {
+					return f / 2
+				})),
+				parameter: map[string]interface{}{"price": money{300}},
+				want:      1.5,
+			},
+			{
+				name:       "function argument without converter",
+				expression: `half(price)`,
+				extension: NewLanguage(Full(), Function("half", func(f float64) float64 {
+					return f / 2
+				})),
+				parameter: map[string]interface{}{"price": money{300}},
+				wantErr:   "expected type float64 for parameter 0 but got gval.money",
+			},
+		},
+		t,
+	)
+}
+
+func TestConverters_EvalX(t *testing.T) {
+	lang := NewLanguage(Full(), moneyConverter, onOffConverter)
+	ctx := context.Background()
+
+	eval, err := lang.NewEvaluable("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := eval.EvalFloat64(ctx, map[string]interface{}{"x": money{250}})
+	if err != nil || f != 2.5 {
+		t.Errorf("EvalFloat64() = %v, %v want 2.5", f, err)
+	}
+	i, err := eval.EvalInt(ctx, map[string]interface{}{"x": money{300}})
+	if err != nil || i != 3 {
+		t.Errorf("EvalInt() = %v, %v want 3", i, err)
+	}
+	b, err := eval.EvalBool(ctx, map[string]interface{}{"x": onOff("on")})
+	if err != nil || !b {
+		t.Errorf("EvalBool() = %v, %v want true", b, err)
+	}
+
+	eval, err = Full().NewEvaluable("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eval.EvalFloat64(ctx, map[string]interface{}{"x": money{250}}); err == nil {
+		t.Errorf("EvalFloat64() without converter expected error")
+	}
+}
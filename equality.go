@@ -0,0 +1,211 @@
+package gval
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// EqualityOptions configures DeepEquality.
+type EqualityOptions struct {
+	// IgnoreCase compares strings under Unicode case folding.
+	IgnoreCase bool
+}
+
+// DeepEquality returns a Language whose == and != compare values deeply.
+// Numbers of any type like int, uint, float64 and decimal.Decimal are equal if they have the same value,
+// time.Time values are compared with Equal, values implementing Comparable by their order, and arrays, slices, maps and structs element by element.
+// Add it after the other languages, e.g. gval.Full(gval.DeepEquality(gval.EqualityOptions{})).
+func DeepEquality(options EqualityOptions) Language {
+	e := equality(options)
+	l := NewLanguage(
+		InfixOperator("==", func(a, b interface{}) (interface{}, error) { return e.equal(a, b), nil }),
+		InfixOperator("!=", func(a, b interface{}) (interface{}, error) { return !e.equal(a, b), nil }),
+	)
+	if options.IgnoreCase {
+		l = NewLanguage(l,
+			InfixTextOperator("==", func(a, b string) (interface{}, error) { return strings.EqualFold(a, b), nil }),
+			InfixTextOperator("!=", func(a, b string) (interface{}, error) { return !strings.EqualFold(a, b), nil }),
+		)
+	}
+	return l
+}
+
+type equality EqualityOptions
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// equalValues reports if a and b are deeply equal with numbers compared by value.
+func equalValues(a, b interface{}) bool {
+	return equality{}.equal(a, b)
+}
+
+func (e equality) equal(a, b interface{}) bool {
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			return x == y
+		}
+	}
+	var visited map[visit]bool
+	return e.values(reflect.ValueOf(a), reflect.ValueOf(b), &visited)
+}
+
+// visit is a pair of values under comparison, identified by their references and types.
+type visit struct {
+	a, b   uintptr
+	ta, tb reflect.Type
+}
+
+// values reports if a and b are deeply equal. Like reflect.DeepEqual it
+// considers values equal that are already under comparison in visited
+// to terminate on cyclic maps, slices and pointers. visited is only allocated
+// when references are compared, so comparing scalars doesn't allocate.
+func (e equality) values(a, b reflect.Value, visited *map[visit]bool) bool {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if ra, ok := reference(a); ok {
+		if rb, ok := reference(b); ok {
+			v := visit{ra, rb, a.Type(), b.Type()}
+			if (*visited)[v] {
+				return true
+			}
+			if *visited == nil {
+				*visited = map[visit]bool{}
+			}
+			(*visited)[v] = true
+		}
+	}
+	if x, ok := exactNumber(a); ok {
+		y, ok := exactNumber(b)
+		return ok && x.Equal(y)
+	}
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+	if a.CanInterface() && b.CanInterface() {
+		if _, ok := a.Interface().(Comparable); ok {
+			c, err := compare(a.Interface(), b.Interface())
+			return err == nil && c == 0
+		}
+		if _, ok := b.Interface().(Comparable); ok {
+			c, err := compare(a.Interface(), b.Interface())
+			return err == nil && c == 0
+		}
+	}
+	switch a.Kind() {
+	case reflect.String:
+		if b.Kind() != reflect.String {
+			return false
+		}
+		if e.IgnoreCase {
+			return strings.EqualFold(a.String(), b.String())
+		}
+		return a.String() == b.String()
+	case reflect.Slice, reflect.Array:
+		if b.Kind() != reflect.Slice && b.Kind() != reflect.Array || a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !e.values(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if b.Kind() != reflect.Map || a.Len() != b.Len() {
+			return false
+		}
+		for _, k := range a.MapKeys() {
+			if !e.mapValue(a.MapIndex(k), k, b, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if a.Type() != b.Type() {
+			return false
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Field(i).CanInterface() {
+				return reflect.DeepEqual(a.Interface(), b.Interface())
+			}
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !e.values(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// mapValue reports if the map m contains a key equal to k with a value equal to v.
+func (e equality) mapValue(v, k reflect.Value, m reflect.Value, visited *map[visit]bool) bool {
+	if k.Type().AssignableTo(m.Type().Key()) && !e.IgnoreCase {
+		if w := m.MapIndex(k); w.IsValid() {
+			return e.values(v, w, visited)
+		}
+	}
+	for _, l := range m.MapKeys() {
+		if e.values(k, l, visited) {
+			return e.values(v, m.MapIndex(l), visited)
+		}
+	}
+	return false
+}
+
+// indirect resolves pointers and interfaces.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// reference returns the address of a map, a non empty slice or a value behind a pointer.
+func reference(v reflect.Value) (uintptr, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		return v.Pointer(), !v.IsNil()
+	case reflect.Slice:
+		return v.Pointer(), v.Len() > 0
+	}
+	if v.CanAddr() {
+		return v.UnsafeAddr(), true
+	}
+	return 0, false
+}
+
+// exactNumber returns the exact value of an integer, a finite float or a decimal.Decimal.
+func exactNumber(v reflect.Value) (decimal.Decimal, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(v.Uint()), 0), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return decimal.Decimal{}, false
+		}
+		if v.Kind() == reflect.Float32 {
+			return decimal.NewFromFloat32(float32(f)), true
+		}
+		return decimal.NewFromFloat(f), true
+	case reflect.Struct:
+		if v.Type() == decimalType && v.CanInterface() {
+			return v.Interface().(decimal.Decimal), true
+		}
+	}
+	return decimal.Decimal{}, false
+}
@@ -0,0 +1,83 @@
+package gval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDeepEquality(t *testing.T) {
+	type point struct{ X, Y int }
+	type node struct {
+		Value int
+		Next  *node
+	}
+	cyclic := func(value int) map[string]interface{} {
+		m := map[string]interface{}{"value": value}
+		s := []interface{}{value, nil}
+		s[1] = s
+		n := &node{Value: value}
+		n.Next = n
+		m["self"], m["slice"], m["node"] = m, s, n
+		return m
+	}
+	instant := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	parameter := map[string]interface{}{
+		"ints":    []interface{}{1, int64(2)},
+		"typed":   []int{1, 2},
+		"object":  map[string]interface{}{"a": int32(1), "b": []interface{}{uint(2)}},
+		"decimal": decimal.RequireFromString("1.5"),
+		"utc":     instant,
+		"local":   instant.In(time.FixedZone("CET", 3600)),
+		"point":   point{1, 2},
+		"pointer": &point{1, 2},
+		"upper":   []interface{}{"ABC"},
+		"cyclic":  cyclic(1),
+		"same":    cyclic(1),
+		"other":   cyclic(2),
+	}
+	tests := []evaluationTest{
+		{name: "array with ints", expression: `ints == [1, 2]`, parameter: parameter, want: true},
+		{name: "typed slice", expression: `typed == [1, 2]`, parameter: parameter, want: true},
+		{name: "different length", expression: `typed == [1]`, parameter: parameter, want: false},
+		{name: "object", expression: `object == {"a": 1, "b": [2]}`, parameter: parameter, want: true},
+		{name: "object differs", expression: `object != {"a": 1, "b": [3]}`, parameter: parameter, want: true},
+		{name: "object missing key", expression: `object == {"a": 1, "c": [2]}`, parameter: parameter, want: false},
+		{name: "decimal in array", expression: `[decimal] == [1.5]`, parameter: parameter, want: true},
+		{name: "times in different zones", expression: `utc == local`, parameter: parameter, want: true},
+		{name: "times in array", expression: `[utc] != [local]`, parameter: parameter, want: false},
+		{name: "struct and pointer", expression: `point == pointer`, parameter: parameter, want: true},
+		{name: "case sensitive", expression: `upper == ["abc"]`, parameter: parameter, want: false},
+		{name: "nil", expression: `nil == nil`, parameter: map[string]interface{}{"nil": nil}, want: true},
+		{name: "cyclic", expression: `cyclic == same`, parameter: parameter, want: true},
+		{name: "cyclic differs", expression: `cyclic == other`, parameter: parameter, want: false},
+		{name: "cyclic slice", expression: `cyclic.slice == same.slice && cyclic.node == same.node`, parameter: parameter, want: true},
+	}
+	for i := range tests {
+		tests[i].extension = DeepEquality(EqualityOptions{})
+	}
+	ignoreCase := []evaluationTest{
+		{name: "ignore case", expression: `"Straße" == "STRASSE"`, want: false},
+		{name: "ignore case string", expression: `"Go" == "GO"`, want: true},
+		{name: "ignore case not equal", expression: `"Go" != "GO"`, want: false},
+		{name: "ignore case in array", expression: `upper == ["abc"]`, parameter: parameter, want: true},
+		{name: "ignore case map keys", expression: `{"A": 1} == {"a": 1}`, want: true},
+	}
+	for i := range ignoreCase {
+		ignoreCase[i].extension = DeepEquality(EqualityOptions{IgnoreCase: true})
+	}
+	testEvaluate(append(tests, ignoreCase...), t)
+}
+
+func Test_inArray_allocations(t *testing.T) {
+	var element, array interface{} = "d", []interface{}{"a", "b", "c", "d"}
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := inArray(element, array); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("inArray() allocated %v times, want 0", allocs)
+	}
+}
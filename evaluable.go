@@ -15,6 +15,11 @@ type Selector interface {
 	SelectGVal(c context.Context, key string) (interface{}, error)
 }
 
+// Container allows for custom membership tests of the in operator
+type Container interface {
+	ContainsGVal(element interface{}) (bool, error)
+}
+
 // Evaluable evaluates given parameter
 type Evaluable func(c context.Context, parameter interface{}) (interface{}, error)
 
@@ -25,7 +30,7 @@ func (e Evaluable) EvalInt(c context.Context, parameter interface{}) (int, error
 		return 0, err
 	}
 
-	f, ok := convertToFloat(v)
+	f, ok := e.resultConversion(c).number(v)
 	if !ok {
 		return 0, fmt.Errorf("expected number but got %v (%T)", v, v)
 	}
@@ -39,7 +44,7 @@ func (e Evaluable) EvalFloat64(c context.Context, parameter interface{}) (float6
 		return 0, err
 	}
 
-	f, ok := convertToFloat(v)
+	f, ok := e.resultConversion(c).number(v)
 	if !ok {
 		return 0, fmt.Errorf("expected number but got %v (%T)", v, v)
 	}
@@ -53,7 +58,7 @@ func (e Evaluable) EvalBool(c context.Context, parameter interface{}) (bool, err
 		return false, err
 	}
 
-	b, ok := convertToBool(v)
+	b, ok := e.resultConversion(c).boolean(v)
 	if !ok {
 		return false, fmt.Errorf("expected bool but got %v (%T)", v, v)
 	}
@@ -94,9 +99,11 @@ func constant(value interface{}) Evaluable {
 //		struct methods,
 //		slices and
 //	 map with int or string key.
+//
+// The projections [*], [? predicate] and ..key select arrays of values.
 func (p *Parser) Var(path ...Evaluable) Evaluable {
 	if p.selector == nil {
-		return variable(path)
+		return variable(path, p.reflectionPolicy)
 	}
 	return p.selector(path)
 }
@@ -117,46 +124,84 @@ func (evs Evaluables) EvalStrings(c context.Context, parameter interface{}) ([]s
 	return strs, nil
 }
 
-func variable(path Evaluables) Evaluable {
+// IsConst returns if all Evaluables are Parser.Const() values
+func (evs Evaluables) IsConst() bool {
+	for _, e := range evs {
+		if !e.IsConst() {
+			return false
+		}
+	}
+	return true
+}
+
+func variable(path Evaluables, policy *ReflectionPolicy) Evaluable {
 	return func(c context.Context, v interface{}) (interface{}, error) {
-		v2 := v
-		for _, p := range path {
-			k, err := p.EvalString(c, v)
+		return selectPath(c, v, v, path, policy)
+	}
+}
+
+// selectPath selects path on value. The keys of path are evaluated on parameter.
+func selectPath(c context.Context, parameter, value interface{}, path Evaluables, policy *ReflectionPolicy) (interface{}, error) {
+	v, _, err := selectPathProjected(c, parameter, value, path, policy)
+	return v, err
+}
+
+// selectPathProjected selects path on value and reports if a key of path is a projection.
+func selectPathProjected(c context.Context, parameter, value interface{}, path Evaluables, policy *ReflectionPolicy) (interface{}, bool, error) {
+	v2 := value
+	for i, p := range path {
+		key, err := p(c, parameter)
+		if err != nil {
+			return nil, false, err
+		}
+		var k string
+		switch key := key.(type) {
+		case pathProjection:
+			values, err := key.selectAll(c, parameter, v2, policy)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
-			switch o := v2.(type) {
-			case Selector:
-				v2, err = o.SelectGVal(c, k)
-				if err != nil {
-					return nil, fmt.Errorf("failed to select '%s' on %T: %w", k, o, err)
-				}
-				continue
-			case map[interface{}]interface{}:
-				v2 = o[k]
-				continue
-			case map[string]interface{}:
-				v2 = o[k]
+			r, err := selectProjection(c, parameter, values, path[i+1:], policy)
+			return r, true, err
+		case string:
+			k = key
+		default:
+			k = fmt.Sprintf("%v", key)
+		}
+		switch o := v2.(type) {
+		case Selector:
+			v2, err = o.SelectGVal(c, k)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to select '%s' on %T: %w", k, o, err)
+			}
+			continue
+		case map[interface{}]interface{}:
+			v2 = o[k]
+			continue
+		case map[string]interface{}:
+			v2 = o[k]
+			continue
+		case []interface{}:
+			if i, err := strconv.Atoi(k); err == nil && i >= 0 && len(o) > i {
+				v2 = o[i]
 				continue
-			case []interface{}:
-				if i, err := strconv.Atoi(k); err == nil && i >= 0 && len(o) > i {
-					v2 = o[i]
-					continue
-				}
-			default:
-				var ok bool
-				v2, ok = reflectSelect(k, o)
-				if !ok {
-					return nil, fmt.Errorf("unknown parameter '%s' on %T", k, o)
-				}
+			}
+		default:
+			var ok bool
+			v2, ok = reflectSelect(k, o, policy)
+			if !ok {
+				return nil, false, fmt.Errorf("unknown parameter '%s' on %T", k, o)
 			}
 		}
-		return v2, nil
 	}
+	return v2, false, nil
 }
 
-func reflectSelect(key string, value interface{}) (selection interface{}, ok bool) {
+func reflectSelect(key string, value interface{}, policy *ReflectionPolicy) (selection interface{}, ok bool) {
 	vv := reflect.ValueOf(value)
+	if vv.IsValid() && policy.blocks(vv.Type()) {
+		return nil, false
+	}
 	vvElem := resolvePotentialPointer(vv)
 
 	switch vvElem.Kind() {
@@ -167,40 +212,39 @@ func reflectSelect(key string, value interface{}) (selection interface{}, ok boo
 		}
 
 		vvElem = vv.MapIndex(reflect.ValueOf(mapKey))
+		// check the policy before the pointer is resolved
+		if vvElem.IsValid() && policy.blocks(vvElem.Type()) {
+			return nil, false
+		}
 		vvElem = resolvePotentialPointer(vvElem)
 
 		if vvElem.IsValid() {
-			return vvElem.Interface(), true
+			return policy.selection(vvElem)
 		}
 
 		// key didn't exist. Check if there is a bound method
-		method := vv.MethodByName(key)
-		if method.IsValid() {
-			return method.Interface(), true
-		}
+		return policy.method(vv, key)
 
 	case reflect.Slice:
 		if i, err := strconv.Atoi(key); err == nil && i >= 0 && vv.Len() > i {
-			vvElem = resolvePotentialPointer(vv.Index(i))
-			return vvElem.Interface(), true
+			vvElem = vv.Index(i)
+			if policy.blocks(vvElem.Type()) {
+				return nil, false
+			}
+			return policy.selection(resolvePotentialPointer(vvElem))
 		}
 
 		// key not an int. Check if there is a bound method
-		method := vv.MethodByName(key)
-		if method.IsValid() {
-			return method.Interface(), true
-		}
+		return policy.method(vv, key)
 
 	case reflect.Struct:
 		field := vvElem.FieldByName(key)
-		if field.IsValid() {
-			return field.Interface(), true
+		// unexported fields are not accessible
+		if field.IsValid() && field.CanInterface() {
+			return policy.selection(field)
 		}
 
-		method := vv.MethodByName(key)
-		if method.IsValid() {
-			return method.Interface(), true
-		}
+		return policy.method(vv, key)
 	}
 	return nil, false
 }
@@ -259,6 +303,8 @@ func (*Parser) callEvaluable(fullname string, fun Evaluable, args ...Evaluable)
 			return nil, fmt.Errorf("could not call '%s' type %T", fullname, f)
 		}
 
+		conv := contextArgumentConversion(c)
+		t := ff.Type()
 		a := make([]reflect.Value, len(args))
 		for i := range args {
 			arg, err := args[i](c, v)
@@ -266,6 +312,11 @@ func (*Parser) callEvaluable(fullname string, fun Evaluable, args ...Evaluable)
 				return nil, err
 			}
 			a[i] = reflect.ValueOf(arg)
+			if inType := argumentType(t, i); inType != nil && arg != nil && !a[i].Type().AssignableTo(inType) {
+				if converted, ok := convertArgument(conv, arg, inType); ok {
+					a[i] = converted
+				}
+			}
 		}
 
 		rr := ff.Call(a)
@@ -312,8 +363,11 @@ func regEx(a, b Evaluable) (Evaluable, error) {
 			if err != nil {
 				return nil, err
 			}
-			matched, err := regexp.MatchString(b, a)
-			return matched, err
+			regex, err := compileRegexp(c, b)
+			if err != nil {
+				return nil, err
+			}
+			return regex.MatchString(a), nil
 		}, nil
 	}
 	s, err := b.EvalString(context.TODO(), nil)
@@ -324,7 +378,11 @@ func regEx(a, b Evaluable) (Evaluable, error) {
 	if err != nil {
 		return nil, err
 	}
+	size := lazyRegexpSize(s)
 	return func(c context.Context, v interface{}) (interface{}, error) {
+		if err := checkRegexpSize(c, size); err != nil {
+			return nil, err
+		}
 		s, err := a.EvalString(c, v)
 		if err != nil {
 			return nil, err
@@ -344,8 +402,11 @@ func notRegEx(a, b Evaluable) (Evaluable, error) {
 			if err != nil {
 				return nil, err
 			}
-			matched, err := regexp.MatchString(b, a)
-			return !matched, err
+			regex, err := compileRegexp(c, b)
+			if err != nil {
+				return nil, err
+			}
+			return !regex.MatchString(a), nil
 		}, nil
 	}
 	s, err := b.EvalString(context.TODO(), nil)
@@ -356,7 +417,11 @@ func notRegEx(a, b Evaluable) (Evaluable, error) {
 	if err != nil {
 		return nil, err
 	}
+	size := lazyRegexpSize(s)
 	return func(c context.Context, v interface{}) (interface{}, error) {
+		if err := checkRegexpSize(c, size); err != nil {
+			return nil, err
+		}
 		s, err := a.EvalString(c, v)
 		if err != nil {
 			return nil, err
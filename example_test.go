@@ -448,3 +448,24 @@ func ExampleParser_ParseSublanguage() {
 	// Output:
 	// hello world
 }
+
+func ExampleScript() {
+	value, err := gval.Evaluate(`
+		let net = price * quantity
+		let tax = net * 0.2
+		net + tax`,
+		map[string]interface{}{
+			"price":    2.5,
+			"quantity": 4,
+		},
+		gval.Script(),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(value)
+
+	// Output:
+	// 12
+}
@@ -3,11 +3,246 @@ package gval
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
+	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 type function func(ctx context.Context, arguments ...interface{}) (interface{}, error)
 
+// FunctionInfo describes a function.
+type FunctionInfo struct {
+	// Name is set by Language.Functions.
+	Name string
+	// Parameters are derived from the function type.
+	// Given parameter names and types take precedence.
+	// Functions of type func(...interface{}) (interface{}, error) must describe their parameters.
+	Parameters []ParameterInfo
+	// Variadic is derived from the function type.
+	Variadic bool
+	// Results are derived from the function type without a trailing error.
+	Results     []reflect.Type
+	Description string
+	Examples    []string
+	// Pure functions return the same result for the same arguments and have no side effects.
+	// Calls of pure functions with constant arguments are evaluated while parsing.
+	Pure bool
+	// Cost is an estimate of the cost of a call relative to other functions.
+	Cost int
+}
+
+// ParameterInfo describes a parameter of a function.
+type ParameterInfo struct {
+	Name string
+	Type reflect.Type
+}
+
+// overload is an implementation of a function.
+type overload struct {
+	info FunctionInfo
+	call function
+	// in are the parameter types without a leading context.
+	// in is nil if the function accepts any arguments.
+	in       []reflect.Type
+	variadic bool
+	t        reflect.Type
+}
+
+// overloads are the implementations of a function.
+type overloads struct {
+	// replace the overloads of previous Languages
+	replace    bool
+	candidates []overload
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func newOverload(f interface{}, info ...FunctionInfo) overload {
+	o := overload{call: toFunc(f)}
+	if len(info) > 0 {
+		o.info = info[0]
+	}
+	switch f.(type) {
+	case func(arguments ...interface{}) (interface{}, error),
+		func(ctx context.Context, arguments ...interface{}) (interface{}, error):
+		return o
+	}
+	o.t = reflect.TypeOf(f)
+	o.variadic = o.t.IsVariadic()
+	o.in = []reflect.Type{}
+	for i := 0; i < o.t.NumIn(); i++ {
+		if i == 0 && o.t.In(i) == contextType {
+			continue
+		}
+		o.in = append(o.in, o.t.In(i))
+	}
+
+	parameters := make([]ParameterInfo, len(o.in))
+	for i, in := range o.in {
+		parameters[i].Type = in
+		if i < len(o.info.Parameters) {
+			parameters[i].Name = o.info.Parameters[i].Name
+			if o.info.Parameters[i].Type != nil {
+				parameters[i].Type = o.info.Parameters[i].Type
+			}
+		}
+	}
+	o.info.Parameters = parameters
+	o.info.Variadic = o.variadic
+	if o.info.Results == nil {
+		o.info.Results = []reflect.Type{}
+		for i := 0; i < o.t.NumOut(); i++ {
+			if i == o.t.NumOut()-1 && o.t.Out(i).Implements(errorType) {
+				break
+			}
+			o.info.Results = append(o.info.Results, o.t.Out(i))
+		}
+	}
+	return o
+}
+
+// invoke calls the implementation and counts its cost for the budget of the evaluation.
+func (o overload) invoke(c context.Context, args ...interface{}) (interface{}, error) {
+	if err := chargeOperations(c, o.info.Cost); err != nil {
+		return nil, err
+	}
+	return o.call(c, args...)
+}
+
+// acceptsCount reports if the overload accepts n arguments.
+func (o overload) acceptsCount(n int) bool {
+	if o.in == nil {
+		return true
+	}
+	if o.variadic {
+		return n >= len(o.in)-1
+	}
+	return n == len(o.in)
+}
+
+// accepts reports if the overload accepts the arguments.
+// Without conversion all arguments must be assignable to the parameter types.
+func (o overload) accepts(c context.Context, args []interface{}, conversion bool) bool {
+	if o.in == nil || !o.acceptsCount(len(args)) {
+		return conversion && o.in == nil
+	}
+	if conversion {
+		_, err := createCallArguments(c, o.t, args)
+		return err == nil
+	}
+	for i, arg := range args {
+		in := o.in[len(o.in)-1]
+		if i < len(o.in)-1 || !o.variadic {
+			in = o.in[i]
+		} else {
+			in = in.Elem()
+		}
+		if arg == nil {
+			if _, ok := convertValue(reflect.Value{}, in); !ok {
+				return false
+			}
+		} else if !reflect.TypeOf(arg).AssignableTo(in) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o overload) signature(name string) string {
+	if o.in == nil {
+		return name + "(...)"
+	}
+	params := make([]string, len(o.in))
+	for i, in := range o.in {
+		params[i] = in.String()
+		if o.variadic && i == len(o.in)-1 {
+			params[i] = "..." + in.Elem().String()
+		}
+	}
+	return name + "(" + strings.Join(params, ", ") + ")"
+}
+
+func signatures(name string, candidates []overload) string {
+	s := make([]string, len(candidates))
+	for i, o := range candidates {
+		s[i] = o.signature(name)
+	}
+	return strings.Join(s, ", ")
+}
+
+// functionPrefix parses a call of the function with given implementations.
+func functionPrefix(name string, candidates []overload) extension {
+	return func(c context.Context, p *Parser) (eval Evaluable, err error) {
+		args := []Evaluable{}
+		scan := p.Scan()
+		switch scan {
+		case '(':
+			args, err = p.parseArguments(c)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			p.Camouflage("function call", '(')
+		}
+		matching := candidates
+		call := candidates[0].invoke
+		if len(candidates) > 1 {
+			matching = []overload{}
+			for _, o := range candidates {
+				if o.acceptsCount(len(args)) {
+					matching = append(matching, o)
+				}
+			}
+			if len(matching) == 0 {
+				return nil, fmt.Errorf("no matching overload for %s with %d arguments, candidates: %s",
+					name, len(args), signatures(name, candidates))
+			}
+			call = callOverload(name, matching, candidates)
+		}
+		eval = p.callFunc(call, args...)
+		if isPure(matching) && Evaluables(args).IsConst() {
+			v, err := eval(c, nil)
+			if err != nil {
+				return nil, err
+			}
+			eval = p.Const(v)
+		}
+		return p.parseSelectors(c, name, nil, false, eval)
+	}
+}
+
+func isPure(candidates []overload) bool {
+	for _, o := range candidates {
+		if !o.info.Pure {
+			return false
+		}
+	}
+	return true
+}
+
+// callOverload calls the first matching implementation.
+func callOverload(name string, matching, candidates []overload) function {
+	return func(c context.Context, args ...interface{}) (interface{}, error) {
+		for _, conversion := range []bool{false, true} {
+			for _, o := range matching {
+				if o.accepts(c, args, conversion) {
+					return o.invoke(c, args...)
+				}
+			}
+		}
+		types := make([]string, len(args))
+		for i, arg := range args {
+			types[i] = fmt.Sprintf("%T", arg)
+		}
+		return nil, fmt.Errorf("no matching overload for %s(%s), candidates: %s",
+			name, strings.Join(types, ", "), signatures(name, candidates))
+	}
+}
+
 func toFunc(f interface{}) function {
 	if f, ok := f.(func(arguments ...interface{}) (interface{}, error)); ok {
 		return function(func(ctx context.Context, arguments ...interface{}) (interface{}, error) {
@@ -107,6 +342,7 @@ func createCallArguments(ctx context.Context, t reflect.Type, args []interface{}
 		return nil, fmt.Errorf("invalid number of parameters")
 	}
 
+	conv := contextArgumentConversion(ctx)
 	in := make([]reflect.Value, len(args))
 	var inType reflect.Type
 	for i, arg := range args {
@@ -118,11 +354,192 @@ func createCallArguments(ctx context.Context, t reflect.Type, args []interface{}
 		argVal := reflect.ValueOf(arg)
 		if arg == nil {
 			argVal = reflect.Zero(reflect.TypeOf((*interface{})(nil)).Elem())
+			if nilVal, ok := convertValue(reflect.Value{}, inType); ok {
+				argVal = nilVal
+			}
 		} else if !argVal.Type().AssignableTo(inType) {
-			return nil, fmt.Errorf("expected type %s for parameter %d but got %T",
-				inType.String(), i, arg)
+			var ok bool
+			if argVal, ok = convertArgument(conv, arg, inType); !ok {
+				return nil, fmt.Errorf("expected type %s for parameter %d but got %T",
+					inType.String(), i, arg)
+			}
 		}
 		in[i] = argVal
 	}
 	return in, nil
 }
+
+// argumentType returns the type of argument i of function type t or nil if there is none.
+func argumentType(t reflect.Type, i int) reflect.Type {
+	numIn := t.NumIn()
+	switch {
+	case t.IsVariadic() && i >= numIn-1:
+		return t.In(numIn - 1).Elem()
+	case i < numIn:
+		return t.In(i)
+	}
+	return nil
+}
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// convertArgument converts arg to a value of type t.
+// It tries a lossless conversion first and the registered converters afterwards.
+func convertArgument(conv conversion, arg interface{}, t reflect.Type) (reflect.Value, bool) {
+	if v, ok := convertValue(reflect.ValueOf(arg), t); ok {
+		return v, true
+	}
+	var converted interface{}
+	ok := false
+	switch {
+	case t == decimalType:
+		converted, ok = conv.decimal(arg)
+	case isNumberKind(t.Kind()):
+		converted, ok = conv.number(arg)
+	case t.Kind() == reflect.Bool:
+		converted, ok = conv.boolean(arg)
+	case t.Kind() == reflect.String:
+		converted, ok = conv.text(arg)
+	}
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return convertValue(reflect.ValueOf(converted), t)
+}
+
+// convertValue converts v to type t without loss of information:
+//
+//	numbers to other number types if the value fits, e.g. float64(2) to int but not float64(2.5),
+//	slices and arrays element wise to slices,
+//	maps key and value wise to maps,
+//	maps with string keys to structs by field names,
+//	nil to pointers, interfaces, maps, slices, functions and channels,
+//	values to pointers of the converted value.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if !v.IsValid() {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), true
+		}
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Interface {
+		return convertValue(v.Elem(), t)
+	}
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	switch {
+	case isNumberKind(t.Kind()):
+		return convertNumber(v, t)
+	case t.Kind() == reflect.Slice:
+		return convertSlice(v, t)
+	case t.Kind() == reflect.Map:
+		return convertMap(v, t)
+	case t.Kind() == reflect.Struct:
+		return convertStruct(v, t)
+	case t.Kind() == reflect.Ptr:
+		e, ok := convertValue(v, t.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, true
+	}
+	return reflect.Value{}, false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if !isNumberKind(v.Kind()) {
+		return reflect.Value{}, false
+	}
+	converted := v.Convert(t)
+	if isFloatKind(v.Kind()) && isFloatKind(t.Kind()) {
+		// NaN is not equal to itself but converts losslessly
+		return converted, converted.Float() == v.Float() || math.IsNaN(v.Float())
+	}
+	// the conversion is lossless if it can be reverted
+	if converted.Convert(v.Type()).Interface() != v.Interface() {
+		return reflect.Value{}, false
+	}
+	return converted, true
+}
+
+func convertSlice(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}, false
+	}
+	s := reflect.MakeSlice(t, v.Len(), v.Len())
+	for i := 0; i < v.Len(); i++ {
+		e, ok := convertValue(v.Index(i), t.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		s.Index(i).Set(e)
+	}
+	return s, true
+}
+
+func convertMap(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Kind() != reflect.Map {
+		return reflect.Value{}, false
+	}
+	m := reflect.MakeMapWithSize(t, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		key, ok := convertValue(iter.Key(), t.Key())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		e, ok := convertValue(iter.Value(), t.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		m.SetMapIndex(key, e)
+	}
+	return m, true
+}
+
+// convertStruct sets the fields of a new struct by the keys of map v.
+// Field names are matched exactly or case insensitive. Unknown keys fail the conversion.
+func convertStruct(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	s := reflect.New(t).Elem()
+	for iter := v.MapRange(); iter.Next(); {
+		name := iter.Key().String()
+		field, found := t.FieldByName(name)
+		if !found {
+			field, found = t.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+		}
+		if !found || field.PkgPath != "" {
+			return reflect.Value{}, false
+		}
+		e, ok := convertValue(iter.Value(), field.Type)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		// a promoted field of a nil embedded pointer can not be set
+		f, err := s.FieldByIndexErr(field.Index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		f.Set(e)
+	}
+	return s, true
+}
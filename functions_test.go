@@ -3,7 +3,9 @@ package gval
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -130,6 +132,122 @@ func Test_toFunc(t *testing.T) {
 			},
 			wantErr: context.DeadlineExceeded,
 		},
+		{
+			name: "integral float to int",
+			function: func(a int, b int64, c uint8) int64 {
+				return int64(a) + b + int64(c)
+			},
+			arguments: []interface{}{1., 2., 3.},
+			want:      int64(6),
+		},
+		{
+			name:       "fractional float to int",
+			function:   func(a int) {},
+			arguments:  []interface{}{1.5},
+			wantAnyErr: true,
+		},
+		{
+			name:       "overflowing float to uint8",
+			function:   func(a uint8) {},
+			arguments:  []interface{}{256.},
+			wantAnyErr: true,
+		},
+		{
+			name: "float to float32",
+			function: func(a float32) float32 {
+				return a
+			},
+			arguments: []interface{}{0.5},
+			want:      float32(0.5),
+		},
+		{
+			name:       "inexact float to float32",
+			function:   func(a float32) {},
+			arguments:  []interface{}{0.1},
+			wantAnyErr: true,
+		},
+		{
+			name:       "negative float to uint",
+			function:   func(a uint) {},
+			arguments:  []interface{}{-1.},
+			wantAnyErr: true,
+		},
+		{
+			name: "float to duration",
+			function: func(d time.Duration) time.Duration {
+				return d
+			},
+			arguments: []interface{}{1e9},
+			want:      time.Second,
+		},
+		{
+			name: "slice",
+			function: func(s []string, i ...int) int {
+				return len(s) + len(i)
+			},
+			arguments: []interface{}{[]interface{}{"a", "b"}, 1., 2.},
+			want:      4,
+		},
+		{
+			name:       "slice with wrong element",
+			function:   func(s []string) {},
+			arguments:  []interface{}{[]interface{}{"a", 1.}},
+			wantAnyErr: true,
+		},
+		{
+			name: "map",
+			function: func(m map[string]int) int {
+				return m["a"]
+			},
+			arguments: []interface{}{map[string]interface{}{"a": 2.}},
+			want:      2,
+		},
+		{
+			name: "struct",
+			function: func(p struct {
+				Name  string
+				Count int
+				Tags  []string
+			}) string {
+				return fmt.Sprintf("%s %d %v", p.Name, p.Count, p.Tags)
+			},
+			arguments: []interface{}{map[string]interface{}{"Name": "a", "count": 2., "tags": []interface{}{"x"}}},
+			want:      "a 2 [x]",
+		},
+		{
+			name: "struct pointer",
+			function: func(p *struct{ Name string }) string {
+				return p.Name
+			},
+			arguments: []interface{}{map[string]interface{}{"name": "a"}},
+			want:      "a",
+		},
+		{
+			name: "struct with unknown field",
+			function: func(p struct {
+				Name string
+			}) {
+			},
+			arguments:  []interface{}{map[string]interface{}{"Name": "a", "Age": 2.}},
+			wantAnyErr: true,
+		},
+		{
+			name: "struct with field of nil embedded pointer",
+			function: func(p struct {
+				*named
+			}) {
+			},
+			arguments:  []interface{}{map[string]interface{}{"Name": "a"}},
+			wantAnyErr: true,
+		},
+		{
+			name: "nil to pointer",
+			function: func(p *int) bool {
+				return p == nil
+			},
+			arguments: []interface{}{nil},
+			want:      true,
+		},
 		{
 			name: "nil arg",
 			function: func(a interface{}) bool {
@@ -160,3 +278,297 @@ func Test_toFunc(t *testing.T) {
 		})
 	}
 }
+
+type named struct {
+	Name string
+}
+
+type repeater struct{}
+
+func (repeater) Repeat(s []string, count int) string {
+	return strings.Repeat(strings.Join(s, ""), count)
+}
+
+func TestArgumentConversion(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "function",
+				expression: `repeat("ab", 3)`,
+				extension:  Function("repeat", strings.Repeat),
+				want:       "ababab",
+			},
+			{
+				name:       "function with fractional count",
+				expression: `repeat("ab", 1.5)`,
+				extension:  Function("repeat", strings.Repeat),
+				wantErr:    "expected type int for parameter 1 but got float64",
+			},
+			{
+				name:       "function with slice",
+				expression: `join(["a", "b"], "-")`,
+				extension:  Function("join", strings.Join),
+				want:       "a-b",
+			},
+			{
+				name:       "method",
+				expression: `p.Repeat(["a", "b"], 2)`,
+				parameter:  map[string]interface{}{"p": repeater{}},
+				want:       "abab",
+			},
+		},
+		t,
+	)
+}
+
+func TestFunctionOverload(t *testing.T) {
+	round := NewLanguage(
+		Full(),
+		FunctionOverload("round", math.Round),
+		FunctionOverload("round", func(x float64, digits int) float64 {
+			p := math.Pow(10, float64(digits))
+			return math.Round(x*p) / p
+		}),
+	)
+	length := NewLanguage(
+		Full(),
+		FunctionOverload("len", func(s string) string { return "string" }),
+		FunctionOverload("len", func(a []interface{}) string { return "array" }),
+	)
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "one argument",
+				expression: `round(2.345)`,
+				extension:  round,
+				want:       2.,
+			},
+			{
+				name:       "two arguments",
+				expression: `round(2.345, 2)`,
+				extension:  round,
+				want:       2.35,
+			},
+			{
+				name:       "wrong number of arguments",
+				expression: `round(2.345, 2, 1)`,
+				extension:  round,
+				wantErr:    "no matching overload for round with 3 arguments, candidates: round(float64), round(float64, int)",
+			},
+			{
+				name:       "wrong argument type",
+				expression: `round(2.345, 1.5)`,
+				extension:  round,
+				wantErr:    "no matching overload for round(float64, float64), candidates: round(float64), round(float64, int)",
+			},
+			{
+				name:       "string",
+				expression: `len("abc")`,
+				extension:  length,
+				want:       "string",
+			},
+			{
+				name:       "array",
+				expression: `len([1, 2])`,
+				extension:  length,
+				want:       "array",
+			},
+			{
+				name:       "assignable preferred",
+				expression: `f(1) + f(x)`,
+				extension: NewLanguage(
+					Full(),
+					FunctionOverload("f", func(i int) string { return "int" }),
+					FunctionOverload("f", func(f float64) string { return "float" }),
+				),
+				parameter: map[string]interface{}{"x": 1},
+				want:      "floatint",
+			},
+			{
+				name:       "any arguments last",
+				expression: `f(1) + f("a", "b")`,
+				extension: NewLanguage(
+					Full(),
+					FunctionOverload("f", func(arguments ...interface{}) (interface{}, error) { return "any", nil }),
+					FunctionOverload("f", func(f float64) string { return "float" }),
+				),
+				want: "floatany",
+			},
+			{
+				name:       "overload extends function",
+				expression: `f() + f(1)`,
+				extension: NewLanguage(
+					NewLanguage(Full(), Function("f", func() string { return "a" })),
+					FunctionOverload("f", func(float64) string { return "b" }),
+				),
+				want: "ab",
+			},
+			{
+				name:       "function replaces overloads",
+				expression: `f(1)`,
+				extension: NewLanguage(
+					Full(),
+					FunctionOverload("f", func(float64) string { return "a" }),
+					FunctionOverload("f", func(string) string { return "b" }),
+					Function("f", func() string { return "c" }),
+				),
+				wantErr: "invalid number of parameters",
+			},
+			{
+				name:       "constant replaces overloads",
+				expression: `f`,
+				extension: NewLanguage(
+					Full(),
+					FunctionOverload("f", func(float64) string { return "a" }),
+					Constant("f", "c"),
+					FunctionOverload("f", func(string) string { return "b" }),
+				),
+				wantErr: "invalid number of parameters",
+			},
+		},
+		t,
+	)
+}
+
+func TestFunctionInfo(t *testing.T) {
+	lang := NewLanguage(
+		FunctionOverload("round", math.Round, FunctionInfo{
+			Parameters:  []ParameterInfo{{Name: "x"}},
+			Description: "round rounds x to the nearest integer.",
+			Examples:    []string{"round(1.5)"},
+			Pure:        true,
+			Cost:        1,
+		}),
+		FunctionOverload("round", func(ctx context.Context, x float64, digits int) (float64, error) {
+			p := math.Pow(10, float64(digits))
+			return math.Round(x*p) / p, nil
+		}, FunctionInfo{Parameters: []ParameterInfo{{Name: "x"}, {Name: "digits"}}, Pure: true}),
+		Function("join", strings.Join),
+		Function("any", func(arguments ...interface{}) (interface{}, error) { return nil, nil }),
+	)
+	float, integer, str := reflect.TypeOf(0.), reflect.TypeOf(0), reflect.TypeOf("")
+	want := []FunctionInfo{
+		{Name: "any"},
+		{
+			Name:       "join",
+			Parameters: []ParameterInfo{{Type: reflect.TypeOf([]string{})}, {Type: str}},
+			Results:    []reflect.Type{str},
+		},
+		{
+			Name:        "round",
+			Parameters:  []ParameterInfo{{Name: "x", Type: float}},
+			Results:     []reflect.Type{float},
+			Description: "round rounds x to the nearest integer.",
+			Examples:    []string{"round(1.5)"},
+			Pure:        true,
+			Cost:        1,
+		},
+		{
+			Name:       "round",
+			Parameters: []ParameterInfo{{Name: "x", Type: float}, {Name: "digits", Type: integer}},
+			Results:    []reflect.Type{float},
+			Pure:       true,
+		},
+	}
+	if got := lang.Functions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Functions() = %v, want %v", got, want)
+	}
+}
+
+func TestFunctionInfo_Pure(t *testing.T) {
+	calls := 0
+	counter := func(x float64) float64 {
+		calls++
+		return x
+	}
+	tests := []struct {
+		name       string
+		language   Language
+		expression string
+		wantConst  bool
+		wantCalls  int
+	}{
+		{
+			name:       "pure with constant arguments",
+			language:   Function("f", counter, FunctionInfo{Pure: true}),
+			expression: "f(1)",
+			wantConst:  true,
+			wantCalls:  1,
+		},
+		{
+			name:       "pure with variable arguments",
+			language:   Function("f", counter, FunctionInfo{Pure: true}),
+			expression: "f(x)",
+			wantCalls:  2,
+		},
+		{
+			name:       "impure",
+			language:   Function("f", counter),
+			expression: "f(1)",
+			wantCalls:  2,
+		},
+		{
+			name: "partially pure overloads",
+			language: NewLanguage(
+				FunctionOverload("f", counter, FunctionInfo{Pure: true}),
+				FunctionOverload("f", func(x string) string { calls++; return x }),
+			),
+			expression: "f(1)",
+			wantCalls:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			eval, err := NewLanguage(Full(), tt.language).NewEvaluable(tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eval.IsConst() != tt.wantConst {
+				t.Errorf("IsConst() = %v, want %v", eval.IsConst(), tt.wantConst)
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := eval(context.Background(), map[string]interface{}{"x": 1.}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestFunctionResultSelection(t *testing.T) {
+	object := func(key string) map[string]interface{} {
+		return map[string]interface{}{key: []interface{}{1., map[string]interface{}{"b": "nested"}}}
+	}
+	testEvaluate([]evaluationTest{
+		{
+			name:       "field of result",
+			expression: `object("a").a`,
+			extension:  Function("object", object),
+			want:       []interface{}{1., map[string]interface{}{"b": "nested"}},
+		},
+		{
+			name:       "path of result",
+			expression: `object("a").a[1].b`,
+			extension:  Function("object", object),
+			want:       "nested",
+		},
+		{
+			name:       "path of variable call result",
+			expression: `foo.object("x").x[0] + 1`,
+			parameter:  map[string]interface{}{"foo": map[string]interface{}{"object": object}},
+			want:       2.,
+		},
+		{
+			name:       "call of result",
+			expression: `adder(1)(2)`,
+			extension: Function("adder", func(a float64) func(float64) float64 {
+				return func(b float64) float64 { return a + b }
+			}),
+			want: 3.,
+		},
+	}, t)
+}
@@ -1,8 +1,9 @@
 module github.com/PaesslerAG/gval
 
-go 1.15
+go 1.18
 
 require (
 	github.com/PaesslerAG/jsonpath v0.1.0
 	github.com/shopspring/decimal v1.3.1
+	golang.org/x/text v0.3.8
 )
@@ -3,6 +3,9 @@
 //
 // The package contains concrete expression languages for common application in text, arithmetic, decimal arithmetic, propositional logic and so on.
 // They can be used as basis for a custom expression language or to evaluate expressions directly.
+//
+// Expressions of all languages may contain line comments (// comment) and block comments (/* comment */).
+// HashComments adds shell style line comments (# comment).
 package gval
 
 import (
@@ -96,6 +99,22 @@ func PropositionalLogic() Language {
 	return propositionalLogic
 }
 
+// NullLogic contains three-valued logic with nil as unknown value.
+// In contrast to the other languages it does not treat nil as false.
+//
+// Infix operators return nil if an operand is nil,
+// except for operators with short circuit like ?? and &&.
+// Boolean operators like && and || follow the three-valued truth tables
+// (Kleene logic): nil && false is false, nil || true is true,
+// otherwise the result is nil if an operand is nil.
+// Prefix operators return nil for nil.
+// Evaluating to nil signals an unknown result, not false.
+//
+// Function coalesce: coalesce(a, b, ...) returns the first argument that is not nil
+func NullLogic() Language {
+	return nullLogic
+}
+
 // JSON contains json objects ({string:expression,...})
 // and json arrays ([expression, ...])
 func JSON() Language {
@@ -165,11 +184,36 @@ var full = NewLanguage(arithmetic, bitmask, text, propositionalLogic, ljson,
 			}
 		}
 		return nil, fmt.Errorf("date() could not parse %s", s)
+	}, FunctionInfo{
+		Parameters:  []ParameterInfo{{Name: "date", Type: reflect.TypeOf("")}},
+		Results:     []reflect.Type{reflect.TypeOf(time.Time{})},
+		Description: "date parses a date in the ANSIC, Unix, Ruby, Kitchen, RFC 3339 or ISO 8601 format in the local time zone.",
+		Examples:    []string{`date("2014-01-02")`, `date("2014-01-02T15:04:05Z")`},
 	}),
 )
 
 var ternaryOperator = PostfixOperator("?", parseIf)
 
+var nullLogic = func() Language {
+	l := Function("coalesce", func(arguments ...interface{}) (interface{}, error) {
+		for _, a := range arguments {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+	}, FunctionInfo{
+		Parameters:  []ParameterInfo{{Name: "values", Type: reflect.TypeOf((*interface{})(nil)).Elem()}},
+		Variadic:    true,
+		Results:     []reflect.Type{reflect.TypeOf((*interface{})(nil)).Elem()},
+		Description: "coalesce returns the first value that is not nil.",
+		Examples:    []string{`coalesce(a, b, 0)`},
+		Pure:        true,
+	})
+	l.nullLogic = true
+	return l
+}()
+
 var ljson = NewLanguage(
 	PrefixExtension('[', parseJSONArray),
 	PrefixExtension('{', parseJSONObject),
@@ -214,11 +258,11 @@ var decimalArithmetic = NewLanguage(
 	PrefixExtension(scanner.Int, parseDecimal),
 	PrefixExtension(scanner.Float, parseDecimal),
 	PrefixOperator("-", func(c context.Context, v interface{}) (interface{}, error) {
-		i, ok := convertToFloat(v)
+		d, ok := contextConversion(c).decimal(v)
 		if !ok {
 			return nil, fmt.Errorf("unexpected %v(%T) expected number", v, v)
 		}
-		return decimal.NewFromFloat(i).Neg(), nil
+		return d.Neg(), nil
 	}),
 )
 
@@ -230,7 +274,7 @@ var bitmask = NewLanguage(
 	InfixNumberOperator(">>", func(a, b float64) (interface{}, error) { return float64(int64(a) >> uint64(b)), nil }),
 
 	PrefixOperator("~", func(c context.Context, v interface{}) (interface{}, error) {
-		i, ok := convertToFloat(v)
+		i, ok := contextConversion(c).number(v)
 		if !ok {
 			return nil, fmt.Errorf("unexpected %T expected number", v)
 		}
@@ -253,7 +297,7 @@ var text = NewLanguage(
 
 var propositionalLogic = NewLanguage(
 	PrefixOperator("!", func(c context.Context, v interface{}) (interface{}, error) {
-		b, ok := convertToBool(v)
+		b, ok := contextConversion(c).boolean(v)
 		if !ok {
 			return nil, fmt.Errorf("unexpected %T expected bool", v)
 		}
@@ -277,13 +321,15 @@ var parentheses = NewLanguage(
 
 var ident = NewLanguage(
 	PrefixMetaPrefix(scanner.Ident, parseIdent),
+	PrefixExtension('@', parseCurrent),
+	PrefixExtension('.', parseRecursiveRoot),
 )
 
 var base = NewLanguage(
 	PrefixExtension(scanner.Int, parseNumber),
 	PrefixExtension(scanner.Float, parseNumber),
 	PrefixOperator("-", func(c context.Context, v interface{}) (interface{}, error) {
-		i, ok := convertToFloat(v)
+		i, ok := contextConversion(c).number(v)
 		if !ok {
 			return nil, fmt.Errorf("unexpected %v(%T) expected number", v, v)
 		}
@@ -299,6 +345,7 @@ var base = NewLanguage(
 
 	InfixOperator("==", func(a, b interface{}) (interface{}, error) { return reflect.DeepEqual(a, b), nil }),
 	InfixOperator("!=", func(a, b interface{}) (interface{}, error) { return !reflect.DeepEqual(a, b), nil }),
+
 	parentheses,
 
 	Precedence("??", 0),
@@ -219,18 +219,18 @@ func TestModifierTyping(test *testing.T) {
 		{
 			name:       "IN non-array numeric",
 			expression: "1 in 2",
-			wantErr:    "expected type []interface{} for in operator but got float64",
+			wantErr:    "expected array, map or string for in operator but got float64",
 		},
 		{
-			name:       "IN non-array string",
+			name:       "IN number in string",
 			expression: `1 in "foo"`,
-			wantErr:    "expected type []interface{} for in operator but got string",
+			wantErr:    "expected type string for in operator on string but got float64",
 		},
 		{
 
 			name:       "IN non-array boolean",
 			expression: "1 in true",
-			wantErr:    "expected type []interface{} for in operator but got bool",
+			wantErr:    "expected array, map or string for in operator but got bool",
 		},
 		//TernaryTyping
 		{
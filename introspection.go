@@ -0,0 +1,139 @@
+package gval
+
+import (
+	"sort"
+	"text/scanner"
+)
+
+// ElementKind is the kind of an Element of a Language.
+type ElementKind int
+
+// Kinds of Language elements
+const (
+	// FunctionElement is registered with Function or FunctionOverload.
+	FunctionElement ElementKind = iota
+	// ConstantElement is registered with Constant.
+	ConstantElement
+	// PrefixOperatorElement is registered with PrefixOperator.
+	PrefixOperatorElement
+	// PrefixExtensionElement is registered with PrefixExtension, PrefixMetaPrefix or a keyword like case.
+	PrefixExtensionElement
+	// InfixOperatorElement is registered with an Infix...Operator, InfixShortCircuit or InfixEvalOperator.
+	InfixOperatorElement
+	// PostfixOperatorElement is registered with PostfixOperator.
+	PostfixOperatorElement
+)
+
+func (k ElementKind) String() string {
+	switch k {
+	case FunctionElement:
+		return "function"
+	case ConstantElement:
+		return "constant"
+	case PrefixOperatorElement:
+		return "prefix operator"
+	case PrefixExtensionElement:
+		return "prefix extension"
+	case InfixOperatorElement:
+		return "infix operator"
+	case PostfixOperatorElement:
+		return "postfix operator"
+	}
+	return "unknown"
+}
+
+// Element describes a function, constant, operator or prefix extension of a Language.
+type Element struct {
+	Kind ElementKind
+	// Name of the element. Prefix extensions for token types are named
+	// like the token type, e.g. Int, Float, String or Ident.
+	Name string
+	// Value of a constant.
+	Value interface{}
+	// Precedence of an infix or postfix operator.
+	Precedence uint8
+	// Functions describes the overloads of a function.
+	Functions []FunctionInfo
+}
+
+// Elements returns the descriptions of the elements of the Language sorted by kind and name.
+func (l Language) Elements() []Element {
+	elements := []Element{}
+	for key := range l.prefixes {
+		element, ok := l.elements[key]
+		if !ok {
+			element = Element{Kind: PrefixExtensionElement, Name: prefixName(key)}
+		}
+		if element.Kind == FunctionElement {
+			element.Functions = l.functions(element.Name)
+		}
+		elements = append(elements, element)
+	}
+	for name, op := range l.operators {
+		if element, ok := operatorElement(name, op); ok {
+			elements = append(elements, element)
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		if elements[i].Kind != elements[j].Kind {
+			return elements[i].Kind < elements[j].Kind
+		}
+		return elements[i].Name < elements[j].Name
+	})
+	return elements
+}
+
+// Functions returns the descriptions of the functions of the Language
+// registered with Function or FunctionOverload, sorted by name.
+// Every overload has its own description.
+func (l Language) Functions() []FunctionInfo {
+	infos := []FunctionInfo{}
+	for name := range l.overloads {
+		infos = append(infos, l.functions(name)...)
+	}
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// functions returns the descriptions of the overloads of the function with given name.
+func (l Language) functions(name string) []FunctionInfo {
+	infos := []FunctionInfo{}
+	for _, candidate := range l.overloads[name].candidates {
+		info := candidate.info
+		info.Name = name
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// operatorElement describes an operator. It returns false for a precedence without operator.
+func operatorElement(name string, op operator) (Element, bool) {
+	element := Element{Name: name, Precedence: uint8(op.precedence())}
+	switch op.(type) {
+	case *infix, directInfix:
+		element.Kind = InfixOperatorElement
+	case postfix:
+		element.Kind = PostfixOperatorElement
+	default:
+		return element, false
+	}
+	return element, true
+}
+
+func prefixName(key interface{}) string {
+	switch key := key.(type) {
+	case rune:
+		return tokenName(key)
+	case string:
+		return key
+	}
+	return ""
+}
+
+// tokenName returns the name of a token type or the character.
+func tokenName(r rune) string {
+	if r < 0 {
+		return scanner.TokenString(r)
+	}
+	return string(r)
+}
@@ -0,0 +1,80 @@
+package gval
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"text/scanner"
+)
+
+func TestLanguage_Elements(t *testing.T) {
+	parseNothing := func(context.Context, *Parser) (Evaluable, error) { return nil, nil }
+	lang := NewLanguage(
+		Function("f", func(x float64) float64 { return x }),
+		Constant("pi", 3.14),
+		Constant("x", 1),
+		Constant("x", nil),
+		PrefixOperator("-", func(c context.Context, v interface{}) (interface{}, error) { return v, nil }),
+		PrefixExtension(scanner.Int, parseNothing),
+		PrefixExtension('[', parseNothing),
+		InfixNumberOperator("+", func(a, b float64) (interface{}, error) { return a + b, nil }),
+		InfixEvalOperator("like", func(a, b Evaluable) (Evaluable, error) { return a, nil }),
+		PostfixOperator("?", parseIf),
+		Precedence("+", 120),
+		Precedence("**", 200),
+	)
+	want := []Element{
+		{
+			Kind: FunctionElement,
+			Name: "f",
+			Functions: []FunctionInfo{{
+				Name:       "f",
+				Parameters: []ParameterInfo{{Type: reflect.TypeOf(0.)}},
+				Results:    []reflect.Type{reflect.TypeOf(0.)},
+			}},
+		},
+		{Kind: ConstantElement, Name: "pi", Value: 3.14},
+		{Kind: ConstantElement, Name: "x"},
+		{Kind: PrefixOperatorElement, Name: "-"},
+		{Kind: PrefixExtensionElement, Name: "Int"},
+		{Kind: PrefixExtensionElement, Name: "["},
+		{Kind: InfixOperatorElement, Name: "+", Precedence: 120},
+		{Kind: InfixOperatorElement, Name: "like"},
+		{Kind: PostfixOperatorElement, Name: "?"},
+	}
+	if got := lang.Elements(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Elements() = %v, want %v", got, want)
+	}
+}
+
+func TestLanguage_Elements_override(t *testing.T) {
+	lang := NewLanguage(
+		Function("a", func() {}),
+		Constant("a", 1),
+		Constant("b", 1),
+		Function("b", func() {}),
+	)
+	got := lang.Elements()
+	if len(got) != 2 || got[0].Kind != FunctionElement || got[0].Name != "b" || got[1].Kind != ConstantElement || got[1].Name != "a" {
+		t.Errorf("Elements() = %v, want function b and constant a", got)
+	}
+	if fs := lang.Functions(); len(fs) != 1 || fs[0].Name != "b" {
+		t.Errorf("Functions() = %v, want function b", fs)
+	}
+}
+
+func TestElementKind_String(t *testing.T) {
+	for kind, want := range map[ElementKind]string{
+		FunctionElement:        "function",
+		ConstantElement:        "constant",
+		PrefixOperatorElement:  "prefix operator",
+		PrefixExtensionElement: "prefix extension",
+		InfixOperatorElement:   "infix operator",
+		PostfixOperatorElement: "postfix operator",
+		ElementKind(-1):        "unknown",
+	} {
+		if got := kind.String(); got != want {
+			t.Errorf("%d.String() = %s, want %s", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package gval
+
+import (
+	"context"
+)
+
+// Lambda is an anonymous function of one argument, written as name => expression.
+// Functions receive lambdas as arguments, e.g. sum(orders, o => o.total).
+// Lambdas are only parsed by languages containing Lambdas.
+// Within the expression name refers to the argument and shadows a parameter of the same name.
+type Lambda func(c context.Context, argument interface{}) (interface{}, error)
+
+// parseLambda parses the expression of a lambda with the given parameter name.
+func (p *Parser) parseLambda(c context.Context, name string) (Evaluable, error) {
+	b, unbind := p.bindScoped(name)
+	body, err := p.ParseExpression(c)
+	unbind()
+	if err != nil {
+		return nil, err
+	}
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		return Lambda(func(ci context.Context, argument interface{}) (interface{}, error) {
+			if ci == nil {
+				ci = c
+			}
+			if ci == nil {
+				ci = context.Background()
+			}
+			return body(b.bind(ci, argument), v)
+		}), nil
+	}, nil
+}
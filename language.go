@@ -11,13 +11,22 @@ import (
 
 // Language is an expression language
 type Language struct {
-	prefixes        map[interface{}]extension
-	operators       map[string]operator
-	operatorSymbols map[rune]struct{}
-	init            extension
-	def             extension
-	selector        func(Evaluables) Evaluable
-	maxParseDepth   *uint64
+	prefixes         map[interface{}]extension
+	operators        map[string]operator
+	operatorSymbols  map[rune]struct{}
+	init             extension
+	def              extension
+	selector         func(Evaluables) Evaluable
+	maxParseDepth    *uint64
+	hashComments     bool
+	lambdas          bool
+	nullLogic        bool
+	strict           bool
+	converters       []conversion
+	overloads        map[string]overloads
+	elements         map[interface{}]Element
+	budget           *Budget
+	reflectionPolicy *ReflectionPolicy
 }
 
 // NewLanguage returns the union of given Languages as new Language.
@@ -26,10 +35,28 @@ func NewLanguage(bases ...Language) Language {
 	for _, base := range bases {
 		for i, e := range base.prefixes {
 			l.prefixes[i] = e
+			if element, ok := base.elements[i]; ok {
+				l.elements[i] = element
+			} else {
+				delete(l.elements, i)
+			}
+			if name, ok := i.(string); ok {
+				if _, ok := base.overloads[name]; !ok {
+					delete(l.overloads, name)
+				}
+			}
+		}
+		for name, o := range base.overloads {
+			if existing, ok := l.overloads[name]; ok && !o.replace {
+				o.replace = existing.replace
+				o.candidates = append(existing.candidates[:len(existing.candidates):len(existing.candidates)], o.candidates...)
+			}
+			l.overloads[name] = o
+			l.prefixes[name] = functionPrefix(name, o.candidates)
+			l.elements[name] = Element{Kind: FunctionElement, Name: name}
 		}
 		for i, e := range base.operators {
 			l.operators[i] = e.merge(l.operators[i])
-			l.operators[i].initiate(i)
 		}
 		for i := range base.operatorSymbols {
 			l.operatorSymbols[i] = struct{}{}
@@ -46,6 +73,28 @@ func NewLanguage(bases ...Language) Language {
 		if base.maxParseDepth != nil {
 			l.maxParseDepth = base.maxParseDepth
 		}
+		if base.budget != nil {
+			l.budget = base.budget
+		}
+		if base.reflectionPolicy != nil {
+			l.reflectionPolicy = base.reflectionPolicy
+		}
+		if base.hashComments {
+			l.hashComments = true
+		}
+		if base.lambdas {
+			l.lambdas = true
+		}
+		if base.nullLogic {
+			l.nullLogic = true
+		}
+		if base.strict {
+			l.strict = true
+		}
+		l.converters = append(l.converters, base.converters...)
+	}
+	for i, op := range l.operators {
+		op.initiate(i, l)
 	}
 	return l
 }
@@ -55,6 +104,8 @@ func newLanguage() Language {
 		prefixes:        map[interface{}]extension{},
 		operators:       map[string]operator{},
 		operatorSymbols: map[rune]struct{}{},
+		overloads:       map[string]overloads{},
+		elements:        map[interface{}]Element{},
 	}
 }
 
@@ -65,6 +116,11 @@ func (l Language) NewEvaluable(expression string) (Evaluable, error) {
 
 // NewEvaluableWithContext returns an Evaluable for given expression in the specified language using context
 func (l Language) NewEvaluableWithContext(c context.Context, expression string) (Evaluable, error) {
+	var converters *contextConverters
+	if len(l.converters) > 0 || l.strict {
+		converters = newContextConverters(l)
+		c = withConverters(c, converters)
+	}
 	p := newParser(expression, l)
 
 	eval, err := p.parse(c)
@@ -76,6 +132,12 @@ func (l Language) NewEvaluableWithContext(c context.Context, expression string)
 		return nil, fmt.Errorf("parsing error: %s - %d:%d %w", p.scanner.Position, pos.Line, pos.Column, err)
 	}
 
+	if l.budget != nil {
+		eval = l.budget.evaluable(eval)
+	}
+	if converters != nil {
+		eval = convertingEvaluable(eval, converters)
+	}
 	return eval, nil
 }
 
@@ -98,37 +160,48 @@ func (l Language) EvaluateWithContext(c context.Context, expression string, para
 }
 
 // Function returns a Language with given function.
-// Function has no conversion for input types.
+// Arguments are converted to the input types without loss of information,
+// e.g. float64(2) to int, []interface{} to []string or map[string]interface{}
+// to a struct by field names. Registered converters are tried afterwards.
+// The same conversion applies to methods called on parameters.
 //
 // If the function returns an error it must be the last return parameter.
 //
 // If the function has (without the error) more then one return parameter,
 // it returns them as []interface{}.
-func Function(name string, function interface{}) Language {
+//
+// The optional info describes the function, see FunctionInfo.
+func Function(name string, function interface{}, info ...FunctionInfo) Language {
+	return newLanguageFunction(name, overloads{replace: true, candidates: []overload{newOverload(function, info...)}})
+}
+
+// FunctionOverload returns a Language with an additional implementation of
+// the function with given name. Unlike Function it does not replace the
+// implementations of previous Languages in NewLanguage, e.g. round(x) and round(x, digits)
+// or len(string) and len(array).
+//
+// A call selects the first implementation that accepts the number and types of
+// the arguments. Implementations that need no argument conversion are preferred.
+func FunctionOverload(name string, function interface{}, info ...FunctionInfo) Language {
+	return newLanguageFunction(name, overloads{candidates: []overload{newOverload(function, info...)}})
+}
+
+func newLanguageFunction(name string, o overloads) Language {
 	l := newLanguage()
-	l.prefixes[name] = func(c context.Context, p *Parser) (eval Evaluable, err error) {
-		args := []Evaluable{}
-		scan := p.Scan()
-		switch scan {
-		case '(':
-			args, err = p.parseArguments(c)
-			if err != nil {
-				return nil, err
-			}
-		default:
-			p.Camouflage("function call", '(')
-		}
-		return p.callFunc(toFunc(function), args...), nil
-	}
+	l.overloads[name] = o
+	l.prefixes[name] = functionPrefix(name, o.candidates)
+	l.elements[name] = Element{Kind: FunctionElement, Name: name}
 	return l
 }
 
 // Constant returns a Language with given constant
 func Constant(name string, value interface{}) Language {
 	l := newLanguage()
-	l.prefixes[l.makePrefixKey(name)] = func(c context.Context, p *Parser) (eval Evaluable, err error) {
+	key := l.makePrefixKey(name)
+	l.prefixes[key] = func(c context.Context, p *Parser) (eval Evaluable, err error) {
 		return p.Const(value), nil
 	}
+	l.elements[key] = Element{Kind: ConstantElement, Name: name, Value: value}
 	return l
 }
 
@@ -136,6 +209,7 @@ func Constant(name string, value interface{}) Language {
 func PrefixExtension(r rune, ext func(context.Context, *Parser) (Evaluable, error)) Language {
 	l := newLanguage()
 	l.prefixes[r] = ext
+	l.elements[r] = Element{Kind: PrefixExtensionElement, Name: tokenName(r)}
 	return l
 }
 
@@ -159,6 +233,36 @@ func MaxParseDepth(depth uint64) Language {
 	return l
 }
 
+// HashComments returns a Language that treats everything from # to the end of
+// the line as a comment. Line comments (//) and block comments (/* */) are
+// supported by every Language.
+func HashComments() Language {
+	l := newLanguage()
+	l.hashComments = true
+	return l
+}
+
+// Lambdas returns a Language that parses name => expression as a Lambda,
+// e.g. for functions that project the elements of an array.
+// Aggregations contains Lambdas.
+func Lambdas() Language {
+	l := newLanguage()
+	l.lambdas = true
+	return l
+}
+
+// Strict returns a Language whose operators do not convert operands
+// between strings, bools and numbers. E.g. "10" > 9, "1" == 1, "true" && 1 and 1 + "a" fail
+// with an error naming both operand types and -"1" or !1 fail for the operand.
+// Operands are still converted between types of the same kind,
+// e.g. int to float64 or a named string type to string.
+// Strict does not affect functions.
+func Strict() Language {
+	l := newLanguage()
+	l.strict = true
+	return l
+}
+
 // DefaultExtension is a language that runs the given function if no other
 // prefix matches.
 func DefaultExtension(ext func(context.Context, *Parser) (Evaluable, error)) Language {
@@ -180,22 +284,29 @@ func PrefixMetaPrefix(r rune, ext func(context.Context, *Parser) (call string, a
 		}
 		return alternative()
 	}
+	l.elements[r] = Element{Kind: PrefixExtensionElement, Name: tokenName(r)}
 	return l
 }
 
 // PrefixOperator returns a Language with given prefix
 func PrefixOperator(name string, e Evaluable) Language {
 	l := newLanguage()
-	l.prefixes[l.makePrefixKey(name)] = func(c context.Context, p *Parser) (Evaluable, error) {
+	key := l.makePrefixKey(name)
+	l.elements[key] = Element{Kind: PrefixOperatorElement, Name: name}
+	l.prefixes[key] = func(c context.Context, p *Parser) (Evaluable, error) {
 		eval, err := p.ParseNextExpression(c)
 		if err != nil {
 			return nil, err
 		}
+		nullLogic := p.nullLogic
 		prefix := func(c context.Context, v interface{}) (interface{}, error) {
 			a, err := eval(c, v)
 			if err != nil {
 				return nil, err
 			}
+			if nullLogic && a == nil {
+				return nil, nil
+			}
 			return e(c, a)
 		}
 		if eval.IsConst() {
@@ -226,6 +337,11 @@ func InfixOperator(name string, f func(a, b interface{}) (interface{}, error)) L
 	return newLanguageOperator(name, &infix{arbitrary: f})
 }
 
+// comparableOperator for two values of which at least one implements Comparable.
+func comparableOperator(name string, f func(a, b interface{}) (interface{}, error)) Language {
+	return newLanguageOperator(name, &infix{comparable: f})
+}
+
 // InfixShortCircuit operator is called after the left operand is evaluated.
 func InfixShortCircuit(name string, f func(a interface{}) (interface{}, bool)) Language {
 	return newLanguageOperator(name, &infix{shortCircuit: f})
@@ -263,8 +379,8 @@ func InfixEvalOperator(name string, f func(a, b Evaluable) (Evaluable, error)) L
 }
 
 func newLanguageOperator(name string, op operator) Language {
-	op.initiate(name)
 	l := newLanguage()
+	op.initiate(name, l)
 	l.operators[l.makeInfixKey(name)] = op
 	return l
 }
@@ -0,0 +1,241 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+// Math contains mathematical functions and constants.
+//
+//	abs(x), sign(x), floor(x), ceil(x), round(x), round(x, digits)
+//	sqrt(x), exp(x), log(x), log10(x), hypot(x, y), clamp(x, min, max)
+//	sin(x), cos(x), tan(x), asin(x), acos(x), atan(x), atan2(y, x)
+//	min(...) and max(...) of numbers and arrays of numbers, min(array, lambda) and max(array, lambda)
+//	pi, e, inf and nan
+//
+// The functions work on float64. If an argument is a decimal.Decimal, e.g. with
+// DecimalArithmetic, they work on decimal.Decimal. abs, sign, floor, ceil, round,
+// clamp, min and max are exact for decimals, the other functions have float64 precision.
+func Math() Language {
+	return mathLanguage
+}
+
+var mathLanguage = NewLanguage(
+	Function("abs", numberFunction("abs", 1, 1,
+		func(x []float64) float64 { return math.Abs(x[0]) },
+		func(x []decimal.Decimal) decimal.Decimal { return x[0].Abs() },
+	), numberInfo("abs returns the absolute value of x.", `abs(-2)`, "x")),
+	Function("sign", numberFunction("sign", 1, 1,
+		func(x []float64) float64 {
+			switch {
+			case x[0] > 0:
+				return 1
+			case x[0] < 0:
+				return -1
+			}
+			return x[0]
+		},
+		func(x []decimal.Decimal) decimal.Decimal { return decimal.NewFromInt(int64(x[0].Sign())) },
+	), numberInfo("sign returns 1 for positive, -1 for negative x and x otherwise.", `sign(-2)`, "x")),
+	Function("floor", numberFunction("floor", 1, 1,
+		func(x []float64) float64 { return math.Floor(x[0]) },
+		func(x []decimal.Decimal) decimal.Decimal { return x[0].Floor() },
+	), numberInfo("floor returns the greatest integer value less than or equal to x.", `floor(1.5)`, "x")),
+	Function("ceil", numberFunction("ceil", 1, 1,
+		func(x []float64) float64 { return math.Ceil(x[0]) },
+		func(x []decimal.Decimal) decimal.Decimal { return x[0].Ceil() },
+	), numberInfo("ceil returns the least integer value greater than or equal to x.", `ceil(1.5)`, "x")),
+	Function("round", numberFunction("round", 1, 2,
+		func(x []float64) float64 {
+			if len(x) == 1 {
+				return math.Round(x[0])
+			}
+			p := math.Pow(10, math.Trunc(x[1]))
+			return math.Round(x[0]*p) / p
+		},
+		func(x []decimal.Decimal) decimal.Decimal {
+			if len(x) == 1 {
+				return x[0].Round(0)
+			}
+			return x[0].Round(int32(x[1].IntPart()))
+		},
+	), numberInfo("round rounds x half away from zero to digits decimal places, default 0.", `round(2.345, 2)`, "x", "digits")),
+
+	Function("sqrt", floatFunction("sqrt", math.Sqrt), numberInfo("sqrt returns the square root of x.", `sqrt(16)`, "x")),
+	Function("exp", floatFunction("exp", math.Exp), numberInfo("exp returns e**x.", `exp(1)`, "x")),
+	Function("log", floatFunction("log", math.Log), numberInfo("log returns the natural logarithm of x.", `log(e)`, "x")),
+	Function("log10", floatFunction("log10", math.Log10), numberInfo("log10 returns the decimal logarithm of x.", `log10(100)`, "x")),
+	Function("sin", floatFunction("sin", math.Sin), numberInfo("sin returns the sine of the radian argument x.", `sin(pi / 2)`, "x")),
+	Function("cos", floatFunction("cos", math.Cos), numberInfo("cos returns the cosine of the radian argument x.", `cos(pi)`, "x")),
+	Function("tan", floatFunction("tan", math.Tan), numberInfo("tan returns the tangent of the radian argument x.", `tan(pi / 4)`, "x")),
+	Function("asin", floatFunction("asin", math.Asin), numberInfo("asin returns the arcsine, in radians, of x.", `asin(1)`, "x")),
+	Function("acos", floatFunction("acos", math.Acos), numberInfo("acos returns the arccosine, in radians, of x.", `acos(1)`, "x")),
+	Function("atan", floatFunction("atan", math.Atan), numberInfo("atan returns the arctangent, in radians, of x.", `atan(1)`, "x")),
+	Function("atan2", numberFunction("atan2", 2, 2,
+		func(x []float64) float64 { return math.Atan2(x[0], x[1]) }, nil,
+	), numberInfo("atan2 returns the arc tangent of y/x, using the signs of the two to determine the quadrant.", `atan2(1, 1)`, "y", "x")),
+	Function("hypot", numberFunction("hypot", 2, 2,
+		func(x []float64) float64 { return math.Hypot(x[0], x[1]) }, nil,
+	), numberInfo("hypot returns the square root of x*x + y*y.", `hypot(3, 4)`, "x", "y")),
+	Function("clamp", numberFunction("clamp", 3, 3,
+		func(x []float64) float64 { return math.Max(x[1], math.Min(x[0], x[2])) },
+		func(x []decimal.Decimal) decimal.Decimal { return decimal.Max(x[1], decimal.Min(x[0], x[2])) },
+	), numberInfo("clamp limits x to the range from min to max.", `clamp(12, 0, 10)`, "x", "min", "max")),
+
+	minFunction,
+	maxFunction,
+
+	Constant("pi", math.Pi),
+	Constant("e", math.E),
+	Constant("inf", math.Inf(1)),
+	Constant("nan", math.NaN()),
+)
+
+var (
+	minFunction = Function("min", extremeFunction("min", math.Min, decimal.Min),
+		variadicNumberInfo("min returns the smallest number of the arguments and of the arrays in the arguments.", `min(3, [1, 2])`, `min(orders, o => o.total)`))
+	maxFunction = Function("max", extremeFunction("max", math.Max, decimal.Max),
+		variadicNumberInfo("max returns the greatest number of the arguments and of the arrays in the arguments.", `max(3, [1, 2])`, `max(orders, o => o.total)`))
+)
+
+var numberType = reflect.TypeOf(0.)
+
+func numberInfo(description, example string, parameters ...string) FunctionInfo {
+	info := functionInfo(description, example, parameters...)
+	for i := range info.Parameters {
+		info.Parameters[i].Type = numberType
+	}
+	info.Results = []reflect.Type{numberType}
+	return info
+}
+
+func variadicNumberInfo(description string, examples ...string) FunctionInfo {
+	info := functionInfo(description, examples[0], "values")
+	info.Examples = examples
+	info.Parameters[0].Type = reflect.TypeOf((*interface{})(nil)).Elem()
+	info.Variadic = true
+	info.Results = []reflect.Type{numberType}
+	return info
+}
+
+// numberFunction returns a function of min to max number arguments.
+// If an argument is a decimal.Decimal all arguments are converted to decimal.Decimal and
+// the result is computed by d or, if d is nil, by f with float64 precision.
+func numberFunction(name string, min, max int, f func([]float64) float64, d func([]decimal.Decimal) decimal.Decimal) func(context.Context, ...interface{}) (interface{}, error) {
+	return func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		if len(arguments) < min || len(arguments) > max {
+			if min == max {
+				return nil, fmt.Errorf("%s() expects %d arguments but got %d", name, min, len(arguments))
+			}
+			return nil, fmt.Errorf("%s() expects %d to %d arguments but got %d", name, min, max, len(arguments))
+		}
+		return computeNumbers(c, name, arguments, f, d)
+	}
+}
+
+func computeNumbers(c context.Context, name string, arguments []interface{}, f func([]float64) float64, d func([]decimal.Decimal) decimal.Decimal) (interface{}, error) {
+	conv := contextConversion(c)
+	if containsDecimal(arguments) {
+		decimals := make([]decimal.Decimal, len(arguments))
+		for i, a := range arguments {
+			x, ok := conv.decimal(a)
+			if !ok {
+				return nil, fmt.Errorf("%s() expects numbers but got %T", name, a)
+			}
+			decimals[i] = x
+		}
+		if d != nil {
+			return d(decimals), nil
+		}
+		floats := make([]float64, len(decimals))
+		for i, x := range decimals {
+			floats[i], _ = x.Float64()
+		}
+		r := f(floats)
+		if math.IsNaN(r) || math.IsInf(r, 0) {
+			return nil, fmt.Errorf("%s() result %v is not a decimal", name, r)
+		}
+		return decimal.NewFromFloat(r), nil
+	}
+	floats := make([]float64, len(arguments))
+	for i, a := range arguments {
+		x, ok := conv.number(a)
+		if !ok {
+			return nil, fmt.Errorf("%s() expects numbers but got %T", name, a)
+		}
+		floats[i] = x
+	}
+	return f(floats), nil
+}
+
+func floatFunction(name string, f func(float64) float64) func(context.Context, ...interface{}) (interface{}, error) {
+	return numberFunction(name, 1, 1, func(x []float64) float64 { return f(x[0]) }, nil)
+}
+
+// extremeFunction returns min or max of the numbers in the arguments and in arrays of the arguments.
+// An array followed by a Lambda is projected by the Lambda.
+func extremeFunction(name string, f func(a, b float64) float64, d func(first decimal.Decimal, rest ...decimal.Decimal) decimal.Decimal) func(context.Context, ...interface{}) (interface{}, error) {
+	return func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		if len(arguments) == 0 {
+			return nil, fmt.Errorf("%s() expects at least one number", name)
+		}
+		values := flatten(arguments)
+		if _, ok := arguments[len(arguments)-1].(Lambda); ok {
+			var err error
+			values, err = aggregated(c, name, arguments, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%s() expects at least one number", name)
+		}
+		return computeNumbers(c, name, values,
+			func(x []float64) float64 {
+				r := x[0]
+				for _, v := range x[1:] {
+					r = f(r, v)
+				}
+				return r
+			},
+			func(x []decimal.Decimal) decimal.Decimal { return d(x[0], x[1:]...) },
+		)
+	}
+}
+
+// flatten returns the values and the elements of arrays and slices in values.
+func flatten(values []interface{}) []interface{} {
+	flat := []interface{}{}
+	for _, v := range values {
+		switch v := v.(type) {
+		case []interface{}:
+			flat = append(flat, v...)
+			continue
+		case []byte, string, nil:
+			flat = append(flat, v)
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			flat = append(flat, v)
+			continue
+		}
+		for i := 0; i < rv.Len(); i++ {
+			flat = append(flat, rv.Index(i).Interface())
+		}
+	}
+	return flat
+}
+
+func containsDecimal(values []interface{}) bool {
+	for _, v := range values {
+		if _, ok := v.(decimal.Decimal); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package gval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMath(t *testing.T) {
+	tests := []evaluationTest{
+		{name: "abs", expression: `abs(-2.5)`, want: 2.5},
+		{name: "sign", expression: `sign(-2) + sign(0) + sign(3) * 2`, want: 1.},
+		{name: "floor", expression: `floor(1.5)`, want: 1.},
+		{name: "ceil", expression: `ceil(1.5)`, want: 2.},
+		{name: "round", expression: `round(2.5)`, want: 3.},
+		{name: "round digits", expression: `round(2.345, 1)`, want: 2.3},
+		{name: "round negative digits", expression: `round(1250, -2)`, want: 1300.},
+		{name: "round arity", expression: `round(1, 2, 3)`, wantErr: "round() expects 1 to 2 arguments but got 3"},
+		{name: "sqrt", expression: `sqrt(16)`, want: 4.},
+		{name: "exp log", expression: `log(exp(2))`, want: 2.},
+		{name: "log e", expression: `log(e)`, want: 1.},
+		{name: "log10", expression: `log10(1000)`, want: 3.},
+		{name: "sin", expression: `sin(pi / 2)`, want: 1.},
+		{name: "cos", expression: `cos(pi)`, want: -1.},
+		{name: "atan2", expression: `atan2(1, 1) * 4`, want: math.Pi},
+		{name: "hypot", expression: `hypot(3, 4)`, want: 5.},
+		{name: "hypot arity", expression: `hypot(3)`, wantErr: "hypot() expects 2 arguments but got 1"},
+		{name: "clamp above", expression: `clamp(12, 0, 10)`, want: 10.},
+		{name: "clamp below", expression: `clamp(-1, 0, 10)`, want: 0.},
+		{name: "clamp inside", expression: `clamp(5, 0, 10)`, want: 5.},
+		{name: "min", expression: `min(3, 1, 2)`, want: 1.},
+		{name: "max", expression: `max(3, 1, 2)`, want: 3.},
+		{name: "min array", expression: `min([3, 1], 2)`, want: 1.},
+		{name: "max parameter", expression: `max(values)`, parameter: map[string]interface{}{"values": []int{4, 7, 5}}, want: 7.},
+		{name: "min empty", expression: `min([])`, wantErr: "min() expects at least one number"},
+		{name: "max no number", expression: `max(1, true)`, wantErr: "max() expects numbers but got bool"},
+		{name: "inf", expression: `-inf < 0 && inf > 0`, want: true},
+		{name: "nan", expression: `nan == nan`, want: false},
+	}
+	for i := range tests {
+		tests[i].extension = Math()
+	}
+	testEvaluate(tests, t)
+}
+
+func TestMath_decimal(t *testing.T) {
+	tests := []evaluationTest{
+		{name: "abs", expression: `abs(-0.1)`, want: decimal.RequireFromString("0.1")},
+		{name: "round", expression: `round(0.1 + 0.2, 1)`, want: decimal.RequireFromString("0.3")},
+		{name: "floor", expression: `floor(-1.5)`, want: decimal.RequireFromString("-2")},
+		{name: "ceil", expression: `ceil(x)`, parameter: map[string]interface{}{"x": decimal.RequireFromString("1.01")}, want: decimal.RequireFromString("2")},
+		{name: "sign", expression: `sign(-0.3)`, want: decimal.RequireFromString("-1")},
+		{name: "min", expression: `min(0.3, [0.1, 0.2])`, want: decimal.RequireFromString("0.1")},
+		{name: "max", expression: `max(0.3, 0.1)`, want: decimal.RequireFromString("0.3")},
+		{name: "clamp", expression: `clamp(1.5, 0, 1)`, want: decimal.RequireFromString("1")},
+		{name: "sqrt", expression: `sqrt(2.25)`, want: decimal.RequireFromString("1.5")},
+		{name: "log of zero", expression: `log(0)`, wantErr: "log() result -Inf is not a decimal"},
+	}
+	for i := range tests {
+		tests[i].extension = NewLanguage(Math(), DecimalArithmetic())
+		tests[i].equalityFunc = func(x, y interface{}) bool {
+			a, ok := x.(decimal.Decimal)
+			b, ok2 := y.(decimal.Decimal)
+			return ok && ok2 && a.Equal(b)
+		}
+	}
+	testEvaluate(tests, t)
+}
@@ -0,0 +1,32 @@
+package gval
+
+// Module returns a Language with the functions, constants and prefix operators
+// of the languages in the namespace name, e.g. math.sqrt(x) for
+//
+//	gval.Module("math", gval.Function("sqrt", math.Sqrt))
+//
+// Members of modules are resolved while parsing before variables.
+// Therefore a module hides the fields of a parameter with the same name.
+// Modules can be nested. Operators, prefix extensions of tokens and
+// the settings of the languages are not part of the module.
+func Module(name string, languages ...Language) Language {
+	m := NewLanguage(languages...)
+	l := newLanguage()
+	for key, ext := range m.prefixes {
+		member, ok := key.(string)
+		if !ok {
+			continue
+		}
+		fullname := name + "." + member
+		l.prefixes[fullname] = ext
+		if element, ok := m.elements[key]; ok {
+			element.Name = fullname
+			l.elements[fullname] = element
+		}
+		if o, ok := m.overloads[member]; ok {
+			l.overloads[fullname] = o
+			l.prefixes[fullname] = functionPrefix(fullname, o.candidates)
+		}
+	}
+	return l
+}
@@ -0,0 +1,104 @@
+package gval
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestModule(t *testing.T) {
+	mathModule := Module("math",
+		Function("sqrt", math.Sqrt),
+		Constant("pi", math.Pi),
+		Module("int", Function("abs", func(x int) int {
+			if x < 0 {
+				return -x
+			}
+			return x
+		})),
+	)
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "function",
+				expression: `math.sqrt(16) + 1`,
+				extension:  mathModule,
+				want:       5.,
+			},
+			{
+				name:       "constant",
+				expression: `math.pi > 3`,
+				extension:  mathModule,
+				want:       true,
+			},
+			{
+				name:       "nested module",
+				expression: `math.int.abs(-2)`,
+				extension:  mathModule,
+				want:       2,
+			},
+			{
+				name:       "module hides parameter",
+				expression: `math.pi`,
+				extension:  mathModule,
+				parameter:  map[string]interface{}{"math": map[string]interface{}{"pi": 3}},
+				want:       math.Pi,
+			},
+			{
+				name:       "other fields of parameter",
+				expression: `math.e`,
+				extension:  mathModule,
+				parameter:  map[string]interface{}{"math": map[string]interface{}{"e": 2}},
+				want:       2,
+			},
+			{
+				name:       "bracket selector is no member",
+				expression: `math["pi"]`,
+				extension:  mathModule,
+				parameter:  map[string]interface{}{"math": map[string]interface{}{"pi": 3}},
+				want:       3,
+			},
+			{
+				name:       "no global function",
+				expression: `sqrt(16)`,
+				extension:  mathModule,
+				wantErr:    "unknown parameter 'sqrt'",
+			},
+			{
+				name:       "same name in different modules",
+				expression: `math.upper("a") + str.upper("a")`,
+				extension: NewLanguage(
+					Module("math", Function("upper", func(s string) string { return s + "1" })),
+					Module("str", Function("upper", strings.ToUpper)),
+				),
+				want: "a1A",
+			},
+			{
+				name:       "overloads",
+				expression: `str.repeat("a") + str.repeat("b", 2)`,
+				extension: Module("str",
+					FunctionOverload("repeat", func(s string) string { return s }),
+					FunctionOverload("repeat", strings.Repeat),
+				),
+				want: "abb",
+			},
+			{
+				name:       "overload error",
+				expression: `str.repeat()`,
+				extension: Module("str",
+					FunctionOverload("repeat", func(s string) string { return s }),
+					FunctionOverload("repeat", strings.Repeat),
+				),
+				wantErr: "no matching overload for str.repeat with 0 arguments",
+			},
+		},
+		t,
+	)
+}
+
+func TestModule_Functions(t *testing.T) {
+	fs := Module("math", Function("sqrt", math.Sqrt)).Functions()
+	if len(fs) != 1 || fs[0].Name != "math.sqrt" {
+		t.Errorf("Functions() = %v, want math.sqrt", fs)
+	}
+}
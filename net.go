@@ -0,0 +1,146 @@
+package gval
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Net contains functions for network addresses.
+//
+//	ip(s) parses an IPv4 or IPv6 address into an IP
+//	cidr(s) parses a network like "10.0.0.0/8" into a CIDR
+//	isPrivate(ip), isLoopback(ip), isIPv4(ip) and isIPv6(ip) classify addresses
+//	urlParse(u) returns the scheme, user, host, port, path, query and fragment of a URL
+//	hostMatches(host, pattern) matches host names case-insensitively, * matches one label
+//
+// An IP is in a CIDR if the network contains it, e.g. ip(src) in cidr("10.0.0.0/8").
+// IP implements Comparable, the comparison operators of Net order IPs, IPv4 before IPv6.
+// An IPv4-mapped IPv6 address equals its IPv4 address. If one operand is an IP a string operand is parsed as IP.
+func Net() Language {
+	return netLanguage
+}
+
+var netLanguage = NewLanguage(
+	comparison,
+	Function("ip", func(v interface{}) (IP, error) {
+		return toIP("ip", v)
+	}, netInfo("ip parses an IPv4 or IPv6 address.", `ip("10.1.2.3")`, "address", reflect.TypeOf(IP{}))),
+	Function("cidr", func(s string) (CIDR, error) {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return CIDR{}, fmt.Errorf("cidr() %w", err)
+		}
+		return CIDR{prefix.Masked()}, nil
+	}, netInfo("cidr parses an IP network in CIDR notation.", `ip(src) in cidr("10.0.0.0/8")`, "network", reflect.TypeOf(CIDR{}))),
+	Function("isPrivate", ipPredicate("isPrivate", netip.Addr.IsPrivate),
+		netInfo("isPrivate reports if ip is a private address according to RFC 1918 or RFC 4193.", `isPrivate(src)`, "ip", reflect.TypeOf(false))),
+	Function("isLoopback", ipPredicate("isLoopback", netip.Addr.IsLoopback),
+		netInfo("isLoopback reports if ip is a loopback address.", `isLoopback("127.0.0.1")`, "ip", reflect.TypeOf(false))),
+	Function("isIPv4", ipPredicate("isIPv4", netip.Addr.Is4),
+		netInfo("isIPv4 reports if ip is an IPv4 or IPv4-mapped IPv6 address.", `isIPv4(src)`, "ip", reflect.TypeOf(false))),
+	Function("isIPv6", ipPredicate("isIPv6", func(a netip.Addr) bool { return a.Is6() }),
+		netInfo("isIPv6 reports if ip is an IPv6 address that is not IPv4-mapped.", `isIPv6(src)`, "ip", reflect.TypeOf(false))),
+	Function("urlParse", func(s string) (map[string]interface{}, error) {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("urlParse() %w", err)
+		}
+		query := map[string]interface{}{}
+		for key, values := range u.Query() {
+			query[key] = values[0]
+		}
+		return map[string]interface{}{
+			"scheme":   u.Scheme,
+			"user":     u.User.Username(),
+			"host":     u.Hostname(),
+			"port":     u.Port(),
+			"path":     u.Path,
+			"query":    query,
+			"fragment": u.Fragment,
+		}, nil
+	}, netInfo("urlParse parses a URL. query contains the first value of each parameter.", `urlParse(u).host`, "url", reflect.TypeOf(map[string]interface{}{}))),
+	Function("hostMatches", func(host, pattern string) bool {
+		hosts := strings.Split(strings.TrimSuffix(host, "."), ".")
+		patterns := strings.Split(strings.TrimSuffix(pattern, "."), ".")
+		if len(hosts) != len(patterns) {
+			return false
+		}
+		for i, p := range patterns {
+			if p != "*" && !strings.EqualFold(p, hosts[i]) {
+				return false
+			}
+		}
+		return true
+	}, FunctionInfo{
+		Parameters:  []ParameterInfo{{Name: "host"}, {Name: "pattern"}},
+		Description: "hostMatches reports if host matches the pattern. * matches exactly one label.",
+		Examples:    []string{`hostMatches(urlParse(u).host, "*.example.com")`},
+		Pure:        true,
+	}),
+)
+
+func netInfo(description, example, parameter string, result reflect.Type) FunctionInfo {
+	info := functionInfo(description, example, parameter)
+	info.Results = []reflect.Type{result}
+	return info
+}
+
+// IP is an IP address returned by ip(). It implements Comparable.
+type IP struct {
+	netip.Addr
+}
+
+// CompareGVal compares ip with an IP or a string that is parsed as IP.
+func (ip IP) CompareGVal(other interface{}) (int, error) {
+	o, err := toIP("compare", other)
+	if err != nil {
+		return 0, fmt.Errorf("can not compare IP with %T", other)
+	}
+	return ip.Unmap().Compare(o.Unmap()), nil
+}
+
+// CIDR is an IP network returned by cidr(). It implements Container for the in operator.
+type CIDR struct {
+	netip.Prefix
+}
+
+// ContainsGVal reports if the network contains an IP, a string that is parsed as IP or a CIDR.
+func (n CIDR) ContainsGVal(element interface{}) (bool, error) {
+	if sub, ok := element.(CIDR); ok {
+		return sub.Bits() >= n.Bits() && n.Contains(sub.Addr()), nil
+	}
+	ip, err := toIP("in", element)
+	if err != nil {
+		return false, err
+	}
+	return n.Contains(ip.Addr) || n.Contains(ip.Unmap()), nil
+}
+
+func toIP(name string, v interface{}) (IP, error) {
+	switch v := v.(type) {
+	case IP:
+		return v, nil
+	case netip.Addr:
+		return IP{v}, nil
+	case string:
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return IP{}, fmt.Errorf("%s() %w", name, err)
+		}
+		return IP{addr}, nil
+	}
+	return IP{}, fmt.Errorf("%s() expects an IP but got %T", name, v)
+}
+
+func ipPredicate(name string, f func(netip.Addr) bool) func(interface{}) (bool, error) {
+	return func(v interface{}) (bool, error) {
+		ip, err := toIP(name, v)
+		if err != nil {
+			return false, err
+		}
+		return f(ip.Unmap()), nil
+	}
+}
@@ -0,0 +1,54 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestNet(t *testing.T) {
+	request := map[string]interface{}{
+		"src": "10.1.2.3",
+		"url": "https://user@API.example.com:8443/v1/items?limit=10&limit=20#top",
+	}
+	tests := []evaluationTest{
+		{name: "ip in cidr", expression: `ip(src) in cidr("10.0.0.0/8")`, parameter: request, want: true},
+		{name: "ip not in cidr", expression: `ip(src) in cidr("192.168.0.0/16")`, parameter: request, want: false},
+		{name: "string in cidr", expression: `src in cidr("10.1.0.0/16")`, parameter: request, want: true},
+		{name: "mapped ip in cidr", expression: `ip("::ffff:10.0.0.1") in cidr("10.0.0.0/8")`, want: true},
+		{name: "ipv6 in cidr", expression: `ip("2001:db8::1") in cidr("2001:db8::/32")`, want: true},
+		{name: "subnet in cidr", expression: `cidr("10.1.0.0/16") in cidr("10.0.0.0/8") && !(cidr("10.0.0.0/8") in cidr("10.1.0.0/16"))`, want: true},
+		{name: "cidr is masked", expression: `cidr("10.1.2.3/8") == cidr("10.0.0.0/8")`, want: true},
+		{name: "no ip in cidr", expression: `1 in cidr("10.0.0.0/8")`, wantErr: "in() expects an IP but got float64"},
+		{name: "invalid ip", expression: `ip("10.0.0.256")`, wantErr: `ip() ParseAddr("10.0.0.256")`},
+		{name: "invalid cidr", expression: `cidr("10.0.0.0/33")`, wantErr: `cidr() netip.ParsePrefix("10.0.0.0/33")`},
+		{name: "equal", expression: `ip(src) == "10.1.2.3"`, parameter: request, want: true},
+		{name: "mapped equal", expression: `ip("::ffff:10.1.2.3") == ip(src)`, parameter: request, want: true},
+		{name: "not equal", expression: `ip(src) != ip("10.1.2.4")`, parameter: request, want: true},
+		{name: "less", expression: `ip("10.0.0.9") < ip("10.0.0.10")`, want: true},
+		{name: "ipv4 before ipv6", expression: `ip("255.255.255.255") < ip("::1")`, want: true},
+		{name: "isPrivate", expression: `isPrivate(ip(src)) && !isPrivate("8.8.8.8") && isPrivate("fd00::1")`, parameter: request, want: true},
+		{name: "isLoopback", expression: `isLoopback("127.0.0.1") && isLoopback("::1")`, want: true},
+		{name: "isIPv4", expression: `isIPv4("::ffff:1.2.3.4") && !isIPv6("::ffff:1.2.3.4") && isIPv6("::1")`, want: true},
+		{name: "urlParse host", expression: `urlParse(url).host`, parameter: request, want: "API.example.com"},
+		{name: "urlParse", expression: `urlParse(url)`, parameter: request, want: map[string]interface{}{
+			"scheme": "https", "user": "user", "host": "API.example.com", "port": "8443",
+			"path": "/v1/items", "query": map[string]interface{}{"limit": "10"}, "fragment": "top",
+		}},
+		{name: "urlParse query", expression: `urlParse(url).query.limit`, parameter: request, want: "10"},
+		{name: "hostMatches", expression: `hostMatches(urlParse(url).host, "*.example.com")`, parameter: request, want: true},
+		{name: "hostMatches apex", expression: `hostMatches("example.com", "*.example.com")`, want: false},
+		{name: "hostMatches one label", expression: `hostMatches("a.b.example.com", "*.example.com")`, want: false},
+		{name: "hostMatches exact", expression: `hostMatches("Example.com.", "example.com")`, want: true},
+	}
+	for i := range tests {
+		tests[i].extension = Net()
+	}
+	testEvaluate(tests, t)
+}
+
+func TestNet_combined(t *testing.T) {
+	parameter := map[string]interface{}{"arr": []int{1}}
+	testEvaluate([]evaluationTest{
+		{name: "deep equality kept", expression: `[1] == arr && ip("::ffff:1.2.3.4") == "1.2.3.4"`, extension: NewLanguage(DeepEquality(EqualityOptions{}), Net()), parameter: parameter, want: true},
+		{name: "text comparison kept", expression: `"b" > 1`, extension: Net(), want: true},
+	}, t)
+}
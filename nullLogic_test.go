@@ -0,0 +1,146 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestNullLogic(t *testing.T) {
+	unknown := map[string]interface{}{"x": nil, "t": true, "f": false, "n": 3}
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "arithmetic",
+				expression: "x + 1",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "comparison",
+				expression: "x > 3",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "missing field",
+				expression: "missing > 3 || f",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "without NullLogic",
+				expression: "missing > 3 || f",
+				parameter:  unknown,
+				wantErr:    "invalid operation (<nil>) > (float64)",
+			},
+			{
+				name:       "equality",
+				expression: "x == x",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "text",
+				expression: `x + "a"`,
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "unknown and false",
+				expression: "x && f",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       false,
+			},
+			{
+				name:       "false and unknown",
+				expression: "f && x",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       false,
+			},
+			{
+				name:       "unknown and true",
+				expression: "x && t",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "unknown or true",
+				expression: "x || t",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       true,
+			},
+			{
+				name:       "unknown or false",
+				expression: "x || f",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "unknown or unknown",
+				expression: "x || x",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "not unknown",
+				expression: "!x",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "negative unknown",
+				expression: "-x",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "sql keywords",
+				expression: "x > 1 or n > 1",
+				extension:  NewLanguage(SQLOperators(), NullLogic()),
+				parameter:  unknown,
+				want:       true,
+			},
+			{
+				name:       "null coalescence",
+				expression: "x ?? 5",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       5.,
+			},
+			{
+				name:       "coalesce",
+				expression: "coalesce(x, missing, n, 4)",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       3,
+			},
+			{
+				name:       "coalesce nil",
+				expression: "coalesce(x)",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       nil,
+			},
+			{
+				name:       "known values",
+				expression: "n + 1 > 3 && t",
+				extension:  NullLogic(),
+				parameter:  unknown,
+				want:       true,
+			},
+		},
+		t,
+	)
+}
@@ -65,27 +65,37 @@ func (l Language) isOperatorPrefix(op string) bool {
 	return false
 }
 
-func (op *infix) initiate(name string) {
+func (op *infix) initiate(name string, l Language) {
 	f := func(a, b interface{}) (interface{}, error) {
 		return nil, fmt.Errorf("invalid operation (%T) %s (%T)", a, name, b)
 	}
 	if op.arbitrary != nil {
 		f = op.arbitrary
+		if l.strict && (name == "==" || name == "!=") {
+			f = getStrictOpFunc(name, f)
+		}
 	}
 	for _, typeConvertion := range []bool{true, false} {
+		conv := l.conversion(typeConvertion)
 		if op.text != nil && (!typeConvertion || op.arbitrary == nil) {
-			f = getStringOpFunc(op.text, f, typeConvertion)
+			f = getStringOpFunc(op.text, f, conv.text)
 		}
 		if op.boolean != nil {
-			f = getBoolOpFunc(op.boolean, f, typeConvertion)
+			f = getBoolOpFunc(op.boolean, f, conv.boolean)
 		}
 		if op.number != nil {
-			f = getFloatOpFunc(op.number, f, typeConvertion)
+			f = getFloatOpFunc(op.number, f, conv.number)
 		}
 		if op.decimal != nil {
-			f = getDecimalOpFunc(op.decimal, f, typeConvertion)
+			f = getDecimalOpFunc(op.decimal, f, conv.decimal)
 		}
 	}
+	if op.comparable != nil {
+		f = getComparableOpFunc(op.comparable, f)
+	}
+	if l.nullLogic {
+		f = getNullOpFunc(op.boolean, f, op.shortCircuit == nil)
+	}
 	if op.shortCircuit == nil {
 		op.builder = func(a, b Evaluable) (Evaluable, error) {
 			return func(c context.Context, x interface{}) (interface{}, error) {
@@ -123,20 +133,92 @@ func (op *infix) initiate(name string) {
 
 type opFunc func(a, b interface{}) (interface{}, error)
 
-func getStringOpFunc(s func(a, b string) (interface{}, error), f opFunc, typeConversion bool) opFunc {
-	if typeConversion {
-		return func(a, b interface{}) (interface{}, error) {
-			if a != nil && b != nil {
-				return s(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
-			}
-			return f(a, b)
+// conversion contains the operand conversions of an operator type conversion pass.
+type conversion struct {
+	text    func(interface{}) (string, bool)
+	boolean func(interface{}) (bool, bool)
+	number  func(interface{}) (float64, bool)
+	decimal func(interface{}) (decimal.Decimal, bool)
+}
+
+// exactConversion accepts only operands of the exact operator type.
+var exactConversion = conversion{
+	text: func(o interface{}) (string, bool) {
+		s, ok := o.(string)
+		return s, ok
+	},
+	boolean: func(o interface{}) (bool, bool) {
+		b, ok := o.(bool)
+		return b, ok
+	},
+	number: func(o interface{}) (float64, bool) {
+		f, ok := o.(float64)
+		return f, ok
+	},
+	decimal: func(o interface{}) (decimal.Decimal, bool) {
+		d, ok := o.(decimal.Decimal)
+		return d, ok
+	},
+}
+
+// typeConversion converts operands between strings, bools and numbers.
+var typeConversion = conversion{
+	text: func(o interface{}) (string, bool) {
+		if o == nil {
+			return "", false
 		}
+		return fmt.Sprintf("%v", o), true
+	},
+	boolean: convertToBool,
+	number:  convertToFloat,
+	decimal: convertToDecimal,
+}
+
+// strictConversion converts only between types of the same kind,
+// e.g. int to float64 or a named string type to string.
+var strictConversion = conversion{
+	text: func(o interface{}) (string, bool) {
+		v := resolvePointers(reflect.ValueOf(o))
+		if v.Kind() != reflect.String {
+			return "", false
+		}
+		return v.String(), true
+	},
+	boolean: func(o interface{}) (bool, bool) {
+		v := resolvePointers(reflect.ValueOf(o))
+		if v.Kind() != reflect.Bool {
+			return false, false
+		}
+		return v.Bool(), true
+	},
+	number:  convertNumberToFloat,
+	decimal: convertNumberToDecimal,
+}
+
+func (l Language) conversion(typeConvertion bool) conversion {
+	switch {
+	case !typeConvertion:
+		return exactConversion
+	case l.strict:
+		return strictConversion.with(l.converters)
+	default:
+		return typeConversion.with(l.converters)
 	}
+}
+
+func resolvePointers(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
+
+func getStringOpFunc(s func(a, b string) (interface{}, error), f opFunc, convert func(interface{}) (string, bool)) opFunc {
 	return func(a, b interface{}) (interface{}, error) {
-		s1, k := a.(string)
-		s2, l := b.(string)
+		x, k := convert(a)
+		y, l := convert(b)
 		if k && l {
-			return s(s1, s2)
+			return s(x, y)
 		}
 		return f(a, b)
 	}
@@ -178,20 +260,10 @@ func convertToBool(o interface{}) (bool, bool) {
 	}
 	return false, false
 }
-func getBoolOpFunc(o func(a, b bool) (interface{}, error), f opFunc, typeConversion bool) opFunc {
-	if typeConversion {
-		return func(a, b interface{}) (interface{}, error) {
-			x, k := convertToBool(a)
-			y, l := convertToBool(b)
-			if k && l {
-				return o(x, y)
-			}
-			return f(a, b)
-		}
-	}
+func getBoolOpFunc(o func(a, b bool) (interface{}, error), f opFunc, convert func(interface{}) (bool, bool)) opFunc {
 	return func(a, b interface{}) (interface{}, error) {
-		x, k := a.(bool)
-		y, l := b.(bool)
+		x, k := convert(a)
+		y, l := convert(b)
 		if k && l {
 			return o(x, y)
 		}
@@ -199,6 +271,12 @@ func getBoolOpFunc(o func(a, b bool) (interface{}, error), f opFunc, typeConvers
 	}
 }
 func convertToFloat(o interface{}) (float64, bool) {
+	return toFloat(o, true)
+}
+func convertNumberToFloat(o interface{}) (float64, bool) {
+	return toFloat(o, false)
+}
+func toFloat(o interface{}, parseString bool) (float64, bool) {
 	if i, ok := o.(float64); ok {
 		return i, true
 	}
@@ -218,7 +296,7 @@ func convertToFloat(o interface{}) (float64, bool) {
 	case reflect.Float32, reflect.Float64:
 		return v.Float(), true
 	}
-	if s, ok := o.(string); ok {
+	if s, ok := o.(string); ok && parseString {
 		f, err := strconv.ParseFloat(s, 64)
 		if err == nil {
 			return f, true
@@ -226,21 +304,10 @@ func convertToFloat(o interface{}) (float64, bool) {
 	}
 	return 0, false
 }
-func getFloatOpFunc(o func(a, b float64) (interface{}, error), f opFunc, typeConversion bool) opFunc {
-	if typeConversion {
-		return func(a, b interface{}) (interface{}, error) {
-			x, k := convertToFloat(a)
-			y, l := convertToFloat(b)
-			if k && l {
-				return o(x, y)
-			}
-
-			return f(a, b)
-		}
-	}
+func getFloatOpFunc(o func(a, b float64) (interface{}, error), f opFunc, convert func(interface{}) (float64, bool)) opFunc {
 	return func(a, b interface{}) (interface{}, error) {
-		x, k := a.(float64)
-		y, l := b.(float64)
+		x, k := convert(a)
+		y, l := convert(b)
 		if k && l {
 			return o(x, y)
 		}
@@ -249,6 +316,12 @@ func getFloatOpFunc(o func(a, b float64) (interface{}, error), f opFunc, typeCon
 	}
 }
 func convertToDecimal(o interface{}) (decimal.Decimal, bool) {
+	return toDecimal(o, true)
+}
+func convertNumberToDecimal(o interface{}) (decimal.Decimal, bool) {
+	return toDecimal(o, false)
+}
+func toDecimal(o interface{}, parseString bool) (decimal.Decimal, bool) {
 	if i, ok := o.(decimal.Decimal); ok {
 		return i, true
 	}
@@ -271,7 +344,7 @@ func convertToDecimal(o interface{}) (decimal.Decimal, bool) {
 	case reflect.Float32, reflect.Float64:
 		return decimal.NewFromFloat(v.Float()), true
 	}
-	if s, ok := o.(string); ok {
+	if s, ok := o.(string); ok && parseString {
 		f, err := strconv.ParseFloat(s, 64)
 		if err == nil {
 			return decimal.NewFromFloat(f), true
@@ -279,25 +352,94 @@ func convertToDecimal(o interface{}) (decimal.Decimal, bool) {
 	}
 	return decimal.Zero, false
 }
-func getDecimalOpFunc(o func(a, b decimal.Decimal) (interface{}, error), f opFunc, typeConversion bool) opFunc {
-	if typeConversion {
-		return func(a, b interface{}) (interface{}, error) {
-			x, k := convertToDecimal(a)
-			y, l := convertToDecimal(b)
-			if k && l {
-				return o(x, y)
-			}
+func getDecimalOpFunc(o func(a, b decimal.Decimal) (interface{}, error), f opFunc, convert func(interface{}) (decimal.Decimal, bool)) opFunc {
+	return func(a, b interface{}) (interface{}, error) {
+		x, k := convert(a)
+		y, l := convert(b)
+		if k && l {
+			return o(x, y)
+		}
 
-			return f(a, b)
+		return f(a, b)
+	}
+}
+
+// getStrictOpFunc returns an opFunc that fails for a string, a bool or a number
+// with an operand of another of these kinds instead of calling f.
+func getStrictOpFunc(name string, f opFunc) opFunc {
+	return func(a, b interface{}) (interface{}, error) {
+		if k, l := strictKind(a), strictKind(b); k != reflect.Invalid && l != reflect.Invalid && k != l {
+			return nil, fmt.Errorf("invalid operation (%T) %s (%T)", a, name, b)
 		}
+		return f(a, b)
 	}
+}
+
+// strictKind returns String, Bool or Float64 for strings, bools and numbers and Invalid for other values.
+func strictKind(o interface{}) reflect.Kind {
+	if _, ok := strictConversion.text(o); ok {
+		return reflect.String
+	}
+	if _, ok := strictConversion.boolean(o); ok {
+		return reflect.Bool
+	}
+	if _, ok := strictConversion.decimal(o); ok {
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+// getComparableOpFunc returns an opFunc that calls o if an operand implements Comparable.
+func getComparableOpFunc(o func(a, b interface{}) (interface{}, error), f opFunc) opFunc {
 	return func(a, b interface{}) (interface{}, error) {
-		x, k := a.(decimal.Decimal)
-		y, l := b.(decimal.Decimal)
-		if k && l {
-			return o(x, y)
+		_, k := a.(Comparable)
+		_, l := b.(Comparable)
+		if k || l {
+			return o(a, b)
 		}
+		return f(a, b)
+	}
+}
 
+// getNullOpFunc returns an opFunc for three-valued logic with nil as unknown.
+// If an operand is nil, boolean operators return the result that holds for
+// true and false in place of nil, or nil if the results differ.
+// Other operators return nil if propagate is set.
+func getNullOpFunc(o func(a, b bool) (interface{}, error), f opFunc, propagate bool) opFunc {
+	possible := func(o interface{}) ([]bool, bool) {
+		if o == nil {
+			return []bool{false, true}, true
+		}
+		b, ok := convertToBool(o)
+		return []bool{b}, ok
+	}
+	return func(a, b interface{}) (interface{}, error) {
+		if a != nil && b != nil {
+			return f(a, b)
+		}
+		if o != nil {
+			xs, k := possible(a)
+			ys, l := possible(b)
+			if k && l {
+				var r interface{}
+				for i, x := range xs {
+					for j, y := range ys {
+						ri, err := o(x, y)
+						if err != nil {
+							return nil, err
+						}
+						if i+j > 0 && ri != r {
+							return nil, nil
+						}
+						r = ri
+					}
+				}
+				return r, nil
+			}
+		}
+		if propagate {
+			return nil, nil
+		}
 		return f(a, b)
 	}
 }
@@ -305,7 +447,7 @@ func getDecimalOpFunc(o func(a, b decimal.Decimal) (interface{}, error), f opFun
 type operator interface {
 	merge(operator) operator
 	precedence() operatorPrecedence
-	initiate(name string)
+	initiate(name string, l Language)
 }
 
 type operatorPrecedence uint8
@@ -327,15 +469,17 @@ func (pre operatorPrecedence) precedence() operatorPrecedence {
 	return pre
 }
 
-func (pre operatorPrecedence) initiate(name string) {}
+func (pre operatorPrecedence) initiate(name string, l Language) {}
 
 type infix struct {
 	operatorPrecedence
-	number       func(a, b float64) (interface{}, error)
-	decimal      func(a, b decimal.Decimal) (interface{}, error)
-	boolean      func(a, b bool) (interface{}, error)
-	text         func(a, b string) (interface{}, error)
-	arbitrary    func(a, b interface{}) (interface{}, error)
+	number    func(a, b float64) (interface{}, error)
+	decimal   func(a, b decimal.Decimal) (interface{}, error)
+	boolean   func(a, b bool) (interface{}, error)
+	text      func(a, b string) (interface{}, error)
+	arbitrary func(a, b interface{}) (interface{}, error)
+	// comparable is called if an operand implements Comparable
+	comparable   func(a, b interface{}) (interface{}, error)
 	shortCircuit func(a interface{}) (interface{}, bool)
 	builder      infixBuilder
 }
@@ -358,6 +502,9 @@ func (op infix) merge(op2 operator) operator {
 		if op.arbitrary == nil {
 			op.arbitrary = op2.arbitrary
 		}
+		if op.comparable == nil {
+			op.comparable = op2.comparable
+		}
 		if op.shortCircuit == nil {
 			op.shortCircuit = op2.shortCircuit
 		}
@@ -78,7 +78,7 @@ func Test_Infix(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.infix.initiate("<" + tt.name + ">")
+			tt.infix.initiate("<"+tt.name+">", newLanguage())
 			builder := tt.infix.builder
 			for _, tt := range tt.subTests {
 				t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,180 @@
+package gval
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Comparable is implemented by types that define their order for the comparison operators
+// of Ordering, Semver and Net.
+type Comparable interface {
+	// CompareGVal returns a negative number if the value is less than other,
+	// zero if it is equal and a positive number if it is greater than other.
+	// It returns an error if the value can not be compared with other.
+	CompareGVal(other interface{}) (int, error)
+}
+
+// Ordering contains the comparison operators <, <=, > and >= for
+//
+//	values implementing Comparable,
+//	numbers of any type including decimal.Decimal,
+//	time.Time and time.Duration,
+//	strings in natural order, e.g. "file9" < "file10" and
+//	arrays and slices in lexicographical order of their elements.
+//
+// Add it after the other languages, e.g. gval.Full(gval.Ordering()).
+func Ordering() Language {
+	return ordering
+}
+
+var ordering = NewLanguage(
+	comparison,
+	orderingOperator("<", func(c int) bool { return c < 0 }),
+	orderingOperator("<=", func(c int) bool { return c <= 0 }),
+	orderingOperator(">", func(c int) bool { return c > 0 }),
+	orderingOperator(">=", func(c int) bool { return c >= 0 }),
+)
+
+// comparison contains the comparison operators ==, !=, <, <=, > and >= for operands implementing Comparable.
+// Other operands are left to the operators of the languages it is combined with, e.g. DeepEquality.
+var comparison = NewLanguage(
+	comparableOperator("==", equalComparable(true)),
+	comparableOperator("!=", equalComparable(false)),
+	comparableOperator("<", orderComparable("<", func(c int) bool { return c < 0 })),
+	comparableOperator("<=", orderComparable("<=", func(c int) bool { return c <= 0 })),
+	comparableOperator(">", orderComparable(">", func(c int) bool { return c > 0 })),
+	comparableOperator(">=", orderComparable(">=", func(c int) bool { return c >= 0 })),
+)
+
+func orderingOperator(name string, holds func(int) bool) Language {
+	return NewLanguage(
+		InfixOperator(name, orderComparable(name, holds)),
+		InfixTextOperator(name, func(a, b string) (interface{}, error) {
+			return holds(naturalCompare(a, b)), nil
+		}),
+	)
+}
+
+// orderComparable returns an ordering operator for the operands of compare.
+func orderComparable(name string, holds func(int) bool) func(a, b interface{}) (interface{}, error) {
+	return func(a, b interface{}) (interface{}, error) {
+		c, err := compare(a, b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation (%T) %s (%T): %w", a, name, b, err)
+		}
+		return holds(c), nil
+	}
+}
+
+// equalComparable returns an equality operator for the operands of compare.
+// Operands that can not be compared are not equal.
+func equalComparable(equal bool) func(a, b interface{}) (interface{}, error) {
+	return func(a, b interface{}) (interface{}, error) {
+		c, err := compare(a, b)
+		return (err == nil && c == 0) == equal, nil
+	}
+}
+
+// compare returns the order of a and b.
+func compare(a, b interface{}) (int, error) {
+	if x, ok := a.(Comparable); ok {
+		return x.CompareGVal(b)
+	}
+	if y, ok := b.(Comparable); ok {
+		c, err := y.CompareGVal(a)
+		return -c, err
+	}
+	return compareValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func compareValues(a, b reflect.Value) (int, error) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, fmt.Errorf("nil is not ordered")
+	}
+	if a.CanInterface() && b.CanInterface() {
+		if _, ok := a.Interface().(Comparable); ok {
+			return compare(a.Interface(), b.Interface())
+		}
+		if _, ok := b.Interface().(Comparable); ok {
+			return compare(a.Interface(), b.Interface())
+		}
+	}
+	if x, ok := exactNumber(a); ok {
+		if y, ok := exactNumber(b); ok {
+			return x.Cmp(y), nil
+		}
+	}
+	if a.Type() == timeType && b.Type() == timeType {
+		x, y := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case x.Before(y):
+			return -1, nil
+		case x.After(y):
+			return 1, nil
+		}
+		return 0, nil
+	}
+	switch a.Kind() {
+	case reflect.String:
+		if b.Kind() == reflect.String {
+			return naturalCompare(a.String(), b.String()), nil
+		}
+	case reflect.Slice, reflect.Array:
+		if b.Kind() != reflect.Slice && b.Kind() != reflect.Array {
+			break
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			c, err := compareValues(a.Index(i), b.Index(i))
+			if err != nil || c != 0 {
+				return c, err
+			}
+		}
+		return a.Len() - b.Len(), nil
+	}
+	return 0, fmt.Errorf("%s and %s are not ordered", a.Type(), b.Type())
+}
+
+// naturalCompare compares strings with runs of digits ordered by their numeric value.
+func naturalCompare(a, b string) int {
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if !isDigit(a[i]) || !isDigit(b[j]) {
+			if a[i] != b[j] {
+				if a[i] < b[j] {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+			continue
+		}
+		si, sj := i, j
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+		x, y := trimZeros(a[si:i]), trimZeros(b[sj:j])
+		switch {
+		case len(x) != len(y):
+			return len(x) - len(y)
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return (len(a) - i) - (len(b) - j)
+}
+
+func trimZeros(digits string) string {
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	return digits
+}
@@ -0,0 +1,67 @@
+package gval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type priority string
+
+func (p priority) CompareGVal(other interface{}) (int, error) {
+	levels := map[string]int{"low": 0, "medium": 1, "high": 2}
+	var o string
+	switch other := other.(type) {
+	case priority:
+		o = string(other)
+	case string:
+		o = other
+	default:
+		return 0, fmt.Errorf("can not compare priority with %T", other)
+	}
+	x, ok := levels[string(p)]
+	y, ok2 := levels[o]
+	if !ok || !ok2 {
+		return 0, fmt.Errorf("unknown priority")
+	}
+	return x - y, nil
+}
+
+func TestOrdering(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	parameter := map[string]interface{}{
+		"now":      now,
+		"later":    now.Add(time.Hour),
+		"timeout":  2 * time.Second,
+		"price":    decimal.RequireFromString("1.10"),
+		"count":    uint16(3),
+		"version":  []int{1, 10, 0},
+		"priority": priority("high"),
+	}
+	tests := []evaluationTest{
+		{name: "times", expression: `now < later`, parameter: parameter, want: true},
+		{name: "times equal", expression: `now >= now`, parameter: parameter, want: true},
+		{name: "durations", expression: `timeout > 1000000000`, parameter: parameter, want: true},
+		{name: "decimal with float", expression: `price > 1.1`, parameter: parameter, want: false},
+		{name: "decimal with float less", expression: `price <= 1.1`, parameter: parameter, want: true},
+		{name: "decimal with uint", expression: `price < count`, parameter: parameter, want: true},
+		{name: "arrays", expression: `version > [1, 9, 5]`, parameter: parameter, want: true},
+		{name: "array prefix", expression: `[1, 2] < [1, 2, 0]`, want: true},
+		{name: "nested arrays", expression: `[[1, "b"]] < [[1, "a"]]`, want: false},
+		{name: "natural strings", expression: `"file9" < "file10"`, want: true},
+		{name: "natural strings with zeros", expression: `"v007" < "v8"`, want: true},
+		{name: "strings", expression: `"abc" < "abd" && "ab" < "abc"`, want: true},
+		{name: "comparable", expression: `priority > "medium"`, parameter: parameter, want: true},
+		{name: "comparable right", expression: `"low" >= priority`, parameter: parameter, want: false},
+		{name: "comparable error", expression: `priority > true`, parameter: parameter, wantErr: "can not compare priority with bool"},
+		{name: "not ordered", expression: `now < [1]`, parameter: parameter, wantErr: "time.Time and []interface {} are not ordered"},
+		{name: "nil", expression: `now < nil`, parameter: map[string]interface{}{"now": now, "nil": nil}, wantErr: "nil is not ordered"},
+		{name: "numbers", expression: `2 > 10`, want: false},
+	}
+	for i := range tests {
+		tests[i].extension = Ordering()
+	}
+	testEvaluate(tests, t)
+}
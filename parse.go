@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"text/scanner"
 
 	"github.com/shopspring/decimal"
@@ -35,7 +36,11 @@ func (p *Parser) ParseExpression(c context.Context) (eval Evaluable, err error)
 		}
 
 		if stack.peek().infixBuilder == nil {
-			return stack.pop().Evaluable, nil
+			eval = stack.pop().Evaluable
+			if eval.IsConst() {
+				return p.metered(eval)
+			}
+			return eval, nil
 		}
 	}
 }
@@ -45,12 +50,16 @@ func (p *Parser) ParseNextExpression(c context.Context) (eval Evaluable, err err
 	scan := p.Scan()
 	ex, ok := p.prefixes[scan]
 	if !ok {
-		if scan != scanner.EOF && p.def != nil {
-			return p.def(c, p)
+		if scan == scanner.EOF || p.def == nil {
+			return nil, p.Expected("extensions")
 		}
-		return nil, p.Expected("extensions")
+		ex = p.def
+	}
+	eval, err = ex(c, p)
+	if err != nil {
+		return nil, err
 	}
-	return ex(c, p)
+	return p.metered(eval)
 }
 
 // ParseSublanguage sets the next language for this parser to parse and calls
@@ -110,6 +119,7 @@ func parseDecimal(c context.Context, p *Parser) (Evaluable, error) {
 }
 
 func parseParentheses(c context.Context, p *Parser) (Evaluable, error) {
+	defer p.nest()()
 	eval, err := p.ParseExpression(c)
 	if err != nil {
 		return nil, err
@@ -127,6 +137,10 @@ func (p *Parser) parseOperator(c context.Context, stack *stageStack, eval Evalua
 		scan := p.Scan()
 		op := p.TokenText()
 		mustOp := false
+		if p.isStatementEnd() {
+			p.Camouflage("operator")
+			return stage{Evaluable: eval}, nil
+		}
 		if p.isSymbolOperation(scan) {
 			scan = p.Peek()
 			for p.isSymbolOperation(scan) && p.isOperatorPrefix(op+string(scan)) {
@@ -138,18 +152,30 @@ func (p *Parser) parseOperator(c context.Context, stack *stageStack, eval Evalua
 		} else if scan != scanner.Ident {
 			p.Camouflage("operator")
 			return stage{Evaluable: eval}, nil
+		} else {
+			// operators may consist of multiple words like "not in"
+			for p.isOperatorPrefix(op + " ") {
+				if p.Scan() != scanner.Ident || !p.isOperatorPrefix(op+" "+p.TokenText()) {
+					if _, ok := p.operators[op].(operatorPrecedence); ok || p.operators[op] == nil {
+						return stage{}, fmt.Errorf("unknown operator %s %s", op, p.TokenText())
+					}
+					p.Camouflage("operator")
+					break
+				}
+				op += " " + p.TokenText()
+			}
 		}
 		switch operator := p.operators[op].(type) {
 		case *infix:
 			return stage{
 				Evaluable:          eval,
-				infixBuilder:       operator.builder,
+				infixBuilder:       p.meteredBuilder(operator.builder),
 				operatorPrecedence: operator.operatorPrecedence,
 			}, nil
 		case directInfix:
 			return stage{
 				Evaluable:          eval,
-				infixBuilder:       operator.infixBuilder,
+				infixBuilder:       p.meteredBuilder(operator.infixBuilder),
 				operatorPrecedence: operator.operatorPrecedence,
 			}, nil
 		case postfix:
@@ -163,6 +189,10 @@ func (p *Parser) parseOperator(c context.Context, stack *stageStack, eval Evalua
 			if err != nil {
 				return
 			}
+			eval, err = p.metered(eval)
+			if err != nil {
+				return
+			}
 			continue
 		}
 
@@ -178,48 +208,153 @@ func parseIdent(c context.Context, p *Parser) (call string, alternative func() (
 	token := p.TokenText()
 	return token,
 		func() (Evaluable, error) {
-			fullname := token
-
-			keys := []Evaluable{p.Const(token)}
-			for {
-				scan := p.Scan()
-				switch scan {
-				case '.':
-					scan = p.Scan()
-					switch scan {
-					case scanner.Ident:
-						token = p.TokenText()
-						keys = append(keys, p.Const(token))
-					default:
-						return nil, p.Expected("field", scanner.Ident)
-					}
-				case '(':
-					args, err := p.parseArguments(c)
-					if err != nil {
-						return nil, err
-					}
-					return p.callEvaluable(fullname, p.Var(keys...), args...), nil
-				case '[':
-					key, err := p.ParseExpression(c)
-					if err != nil {
-						return nil, err
-					}
-					switch p.Scan() {
-					case ']':
-						keys = append(keys, key)
-					default:
-						return nil, p.Expected("array key", ']')
+			if p.Scan() == '=' && p.lambdas && p.Peek() == '>' {
+				p.Next()
+				return p.parseLambda(c, token)
+			}
+			p.Camouflage("variable")
+
+			bound := p.bindings[token]
+			// members of modules are dotted paths of identifiers
+			return p.parseSelectors(c, token, bound, bound == nil, nil, p.Const(token))
+		}, nil
+
+}
+
+// parseCurrent parses @, the current element of a filter, and its selectors.
+func parseCurrent(c context.Context, p *Parser) (Evaluable, error) {
+	bound := p.bindings["@"]
+	if bound == nil {
+		return nil, fmt.Errorf("@ is only defined in filters")
+	}
+	return p.parseSelectors(c, "@", bound, false, nil, p.Const("@"))
+}
+
+// parseRecursiveRoot parses a path that starts with ..key on the parameter.
+func parseRecursiveRoot(c context.Context, p *Parser) (Evaluable, error) {
+	if p.Scan() != '.' {
+		return nil, p.Expected("recursive field", '.')
+	}
+	key, err := p.parseRecursiveKey()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseSelectors(c, "", nil, false, nil, key)
+}
+
+// parseSelectors parses the selectors and the call arguments after the first key of a variable.
+// A member path is looked up in the prefixes of modules.
+// Selectors after a call select on its result. A non nil call is the result of a preceding call.
+func (p *Parser) parseSelectors(c context.Context, fullname string, bound *binding, member bool, call Evaluable, keys ...Evaluable) (Evaluable, error) {
+	path := fullname
+	selection := func() Evaluable {
+		if call == nil {
+			return p.variable(bound, keys)
+		}
+		return p.selection(call, keys)
+	}
+	for {
+		scan := p.Scan()
+		if p.isStatementEnd() {
+			p.Camouflage("variable")
+			return selection(), nil
+		}
+		switch scan {
+		case '.':
+			scan = p.Scan()
+			switch scan {
+			case scanner.Ident:
+				token := p.TokenText()
+				if member {
+					path += "." + token
+					if prefix, ok := p.prefixes[path]; ok {
+						return prefix(c, p)
 					}
-				default:
-					p.Camouflage("variable", '.', '(', '[')
-					return p.Var(keys...), nil
 				}
+				keys = append(keys, p.Const(token))
+			case '.':
+				member = false
+				key, err := p.parseRecursiveKey()
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, key)
+			default:
+				return nil, p.Expected("field", scanner.Ident)
 			}
-		}, nil
+		case '(':
+			args, err := p.parseArguments(c)
+			if err != nil {
+				return nil, err
+			}
+			call = p.callEvaluable(fullname, selection(), args...)
+			keys = nil
+			member = false
+		case '[':
+			member = false
+			key, err := p.parseSelectorKey(c)
+			if err != nil {
+				return nil, err
+			}
+			switch p.Scan() {
+			case ']':
+				keys = append(keys, key)
+			default:
+				return nil, p.Expected("array key", ']')
+			}
+		default:
+			p.Camouflage("variable", '.', '(', '[')
+			return selection(), nil
+		}
+	}
+}
+
+// selection selects path on the result of eval with the default selector.
+func (p *Parser) selection(eval Evaluable, path Evaluables) Evaluable {
+	if len(path) == 0 {
+		return eval
+	}
+	policy := p.reflectionPolicy
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		x, err := eval(c, v)
+		if err != nil {
+			return nil, err
+		}
+		return selectPath(c, v, x, path, policy)
+	}
+}
+
+// parseSelectorKey parses the key inside of brackets, a wildcard * or a filter ? predicate.
+func (p *Parser) parseSelectorKey(c context.Context) (Evaluable, error) {
+	defer p.nest()()
+	switch p.Scan() {
+	case '*':
+		if p.Peek() == ']' {
+			return p.Const(wildcard{}), nil
+		}
+	case '?':
+		current, unbind := p.bindScoped("@")
+		predicate, err := p.ParseExpression(c)
+		unbind()
+		if err != nil {
+			return nil, err
+		}
+		return p.Const(filter{current: current, predicate: predicate}), nil
+	}
+	p.Camouflage("array key")
+	return p.ParseExpression(c)
+}
 
+// parseRecursiveKey parses the key after .. of a recursive selection.
+func (p *Parser) parseRecursiveKey() (Evaluable, error) {
+	if p.Scan() != scanner.Ident {
+		return nil, p.Expected("recursive field", scanner.Ident)
+	}
+	return p.Const(recursiveKey(p.TokenText())), nil
 }
 
 func (p *Parser) parseArguments(c context.Context) (args []Evaluable, err error) {
+	defer p.nest()()
 	if p.Scan() == ')' {
 		return
 	}
@@ -240,21 +375,57 @@ func (p *Parser) parseArguments(c context.Context) (args []Evaluable, err error)
 	}
 }
 
+// inArray reports if a is an element of the array or slice b, a key of the map b, a substring of the string b
+// or contained in the Container b. Numbers are compared by value.
 func inArray(a, b interface{}) (interface{}, error) {
-	col, ok := b.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("expected type []interface{} for in operator but got %T", b)
+	switch col := b.(type) {
+	case Container:
+		return col.ContainsGVal(a)
+	case []interface{}:
+		for _, value := range col {
+			if equalValues(a, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		s, ok := a.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected type string for in operator on string but got %T", a)
+		}
+		return strings.Contains(col, s), nil
+	case map[string]interface{}:
+		s, ok := a.(string)
+		if !ok {
+			return false, nil
+		}
+		_, ok = col[s]
+		return ok, nil
 	}
-	for _, value := range col {
-		if reflect.DeepEqual(a, value) {
-			return true, nil
+	col := reflect.ValueOf(b)
+	switch col.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < col.Len(); i++ {
+			if equalValues(a, col.Index(i).Interface()) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		for _, key := range col.MapKeys() {
+			if equalValues(a, key.Interface()) {
+				return true, nil
+			}
 		}
+		return false, nil
 	}
-	return false, nil
+	return nil, fmt.Errorf("expected array, map or string for in operator but got %T", b)
 }
 
 func parseIf(c context.Context, p *Parser, e Evaluable) (Evaluable, error) {
+	unnest := p.nest()
 	a, err := p.ParseExpression(c)
+	unnest()
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +453,7 @@ func parseIf(c context.Context, p *Parser, e Evaluable) (Evaluable, error) {
 }
 
 func parseJSONArray(c context.Context, p *Parser) (Evaluable, error) {
+	defer p.nest()()
 	evals := []Evaluable{}
 	for {
 		switch p.Scan() {
@@ -311,6 +483,7 @@ func parseJSONArray(c context.Context, p *Parser) (Evaluable, error) {
 }
 
 func parseJSONObject(c context.Context, p *Parser) (Evaluable, error) {
+	defer p.nest()()
 	type kv struct {
 		key   Evaluable
 		value Evaluable
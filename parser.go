@@ -12,9 +12,28 @@ import (
 type Parser struct {
 	scanner scanner.Scanner
 	Language
-	lastScan   rune
+	lastScan rune
+	// newline reports if a line break outside of comments precedes lastScan
+	newline    bool
 	camouflage error
-	parseDepth  uint64
+	parseDepth uint64
+
+	// newlineSeparated lets a line break in front of a token end the
+	// current expression (see Script)
+	newlineSeparated bool
+	// nesting counts the open brackets and ternary branches.
+	// A line break inside them does not end a statement.
+	nesting  int
+	bindings map[string]*binding
+	comments []Comment
+}
+
+// Comment is a comment the Parser skipped while scanning.
+type Comment struct {
+	// Text of the comment including the comment markers
+	Text string
+	// Pos is the start position of the comment
+	Pos scanner.Position
 }
 
 func newParser(expression string, l Language) *Parser {
@@ -29,7 +48,7 @@ func newParser(expression string, l Language) *Parser {
 
 func (p *Parser) resetScannerProperties() {
 	p.scanner.Whitespace = scanner.GoWhitespace
-	p.scanner.Mode = scanner.GoTokens
+	p.scanner.Mode = scanner.GoTokens &^ scanner.SkipComments
 	p.scanner.IsIdentRune = func(r rune, pos int) bool {
 		return unicode.IsLetter(r) || r == '_' || (pos > 0 && unicode.IsDigit(r))
 	}
@@ -66,8 +85,59 @@ func (p *Parser) Scan() rune {
 		return p.lastScan
 	}
 	p.camouflage = nil
-	p.lastScan = p.scanner.Scan()
-	return p.lastScan
+	p.newline = false
+	end := p.scanner.Pos()
+	for {
+		p.lastScan = p.scanner.Scan()
+		if p.scanner.Position.Line > end.Line {
+			p.newline = true
+		}
+		switch {
+		case p.lastScan == scanner.Comment:
+			p.comments = append(p.comments, Comment{p.scanner.TokenText(), p.scanner.Position})
+			end = p.scanner.Pos()
+		case p.lastScan == '#' && p.hashComments:
+			p.comments = append(p.comments, p.scanHashComment())
+			end = p.scanner.Pos()
+		default:
+			return p.lastScan
+		}
+	}
+}
+
+func (p *Parser) scanHashComment() Comment {
+	comment := Comment{Pos: p.scanner.Position}
+	text := []rune{'#'}
+	for r := p.scanner.Peek(); r != '\n' && r != scanner.EOF; r = p.scanner.Peek() {
+		text = append(text, p.scanner.Next())
+	}
+	comment.Text = string(text)
+	return comment
+}
+
+// Comments returns the comments that were skipped so far.
+// Comments are only collected if the scanner mode does not skip them.
+func (p *Parser) Comments() []Comment {
+	return p.comments
+}
+
+// isNewline reports if a line break separates the last scanned token from
+// the token before. Line breaks inside of block comments are ignored.
+func (p *Parser) isNewline() bool {
+	return p.newline
+}
+
+// isStatementEnd reports if the last scanned token starts a new statement.
+// Tokens inside brackets and ternary branches and a ? that starts a ternary
+// continue the statement.
+func (p *Parser) isStatementEnd() bool {
+	return p.newlineSeparated && p.nesting == 0 && p.lastScan != '?' && p.isNewline()
+}
+
+// nest marks the start of a bracket or ternary branch until the returned function is called.
+func (p *Parser) nest() (unnest func()) {
+	p.nesting++
+	return func() { p.nesting-- }
 }
 
 func (p *Parser) isCamouflaged() bool {
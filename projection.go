@@ -0,0 +1,247 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// pathProjection is a key of a variable path that selects several values.
+// The rest of the path is selected on each of them and the results are collected in an array.
+type pathProjection interface {
+	selectAll(c context.Context, parameter, value interface{}, policy *ReflectionPolicy) ([]interface{}, error)
+}
+
+// wildcard selects the elements of slices and arrays and the values of maps ordered by key: [*]
+type wildcard struct{}
+
+// filter selects the elements and map values for which the predicate is true: [? predicate]
+// The predicate refers to the element by @.
+type filter struct {
+	current   *binding
+	predicate Evaluable
+}
+
+// recursiveKey selects the key on the value and on all values it contains: ..key
+type recursiveKey string
+
+func (wildcard) selectAll(c context.Context, parameter, value interface{}, policy *ReflectionPolicy) ([]interface{}, error) {
+	values, ok := children(value, policy)
+	if !ok {
+		return nil, fmt.Errorf("can not select [*] on %T", value)
+	}
+	return values, nil
+}
+
+func (f filter) selectAll(c context.Context, parameter, value interface{}, policy *ReflectionPolicy) ([]interface{}, error) {
+	values, ok := children(value, policy)
+	if !ok {
+		return nil, fmt.Errorf("can not filter %T", value)
+	}
+	if c == nil {
+		c = context.Background()
+	}
+	conv := contextConversion(c)
+	selected := []interface{}{}
+	for _, v := range values {
+		r, err := f.predicate(f.current.bind(c, v), parameter)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := conv.boolean(r)
+		if !ok {
+			return nil, fmt.Errorf("filter expects a bool but got %T", r)
+		}
+		if b {
+			selected = append(selected, v)
+		}
+	}
+	return selected, nil
+}
+
+func (k recursiveKey) selectAll(c context.Context, parameter, value interface{}, policy *ReflectionPolicy) ([]interface{}, error) {
+	selected := []interface{}{}
+	// pointers, maps and slices are visited once to terminate on cyclic values
+	type reference struct {
+		pointer uintptr
+		length  int
+		typ     reflect.Type
+	}
+	visited := map[reference]bool{}
+	// descend charges an operation for each visited node
+	var descend func(v interface{}) error
+	descend = func(v interface{}) error {
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			if rv.IsNil() {
+				break
+			}
+			r := reference{rv.Pointer(), 0, rv.Type()}
+			if rv.Kind() == reflect.Slice {
+				r.length = rv.Len()
+			}
+			if visited[r] {
+				return nil
+			}
+			visited[r] = true
+		}
+		if err := chargeOperations(c, 1); err != nil {
+			return err
+		}
+		if x, ok := selectKey(c, string(k), v, policy); ok {
+			selected = append(selected, x)
+		}
+		values, _ := children(v, policy)
+		if values == nil {
+			values = fields(v, policy)
+		}
+		for _, x := range values {
+			if err := descend(x); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := descend(value); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// selectProjection selects path on each of the values. Arrays selected by further projections are concatenated.
+func selectProjection(c context.Context, parameter interface{}, values []interface{}, path Evaluables, policy *ReflectionPolicy) (interface{}, error) {
+	if err := chargeOperations(c, len(values)); err != nil {
+		return nil, err
+	}
+	results := []interface{}{}
+	for _, v := range values {
+		r, projected, err := selectPathProjected(c, parameter, v, path, policy)
+		if err != nil {
+			return nil, err
+		}
+		if projected {
+			results = append(results, r.([]interface{})...)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// children returns the elements of slices and arrays or the values of maps ordered by key.
+func children(value interface{}, policy *ReflectionPolicy) ([]interface{}, bool) {
+	switch value := value.(type) {
+	case []interface{}:
+		return value, true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, k := range keys {
+			values[i] = value[k]
+		}
+		return values, true
+	case Selector, nil:
+		return nil, false
+	}
+	vv := reflect.ValueOf(value)
+	if policy.blocks(vv.Type()) {
+		return nil, false
+	}
+	vv = resolvePotentialPointer(vv)
+	switch vv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.([]byte); ok {
+			return nil, false
+		}
+		values := []interface{}{}
+		for i := 0; i < vv.Len(); i++ {
+			if v, ok := policy.selection(vv.Index(i)); ok {
+				values = append(values, v)
+			}
+		}
+		return values, true
+	case reflect.Map:
+		keys := vv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		values := []interface{}{}
+		for _, k := range keys {
+			if v, ok := policy.selection(vv.MapIndex(k)); ok {
+				values = append(values, v)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// fields returns the values of the accessible fields of a struct.
+func fields(value interface{}, policy *ReflectionPolicy) []interface{} {
+	if _, ok := value.(Selector); ok || value == nil {
+		return nil
+	}
+	vv := reflect.ValueOf(value)
+	if policy.blocks(vv.Type()) {
+		return nil
+	}
+	vv = resolvePotentialPointer(vv)
+	if vv.Kind() != reflect.Struct {
+		return nil
+	}
+	values := []interface{}{}
+	for i := 0; i < vv.NumField(); i++ {
+		if field := vv.Field(i); field.CanInterface() {
+			if v, ok := policy.selection(field); ok {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// selectKey selects the key of a map or the field of a struct.
+func selectKey(c context.Context, key string, value interface{}, policy *ReflectionPolicy) (interface{}, bool) {
+	switch value := value.(type) {
+	case nil, []interface{}:
+		return nil, false
+	case map[string]interface{}:
+		v, ok := value[key]
+		return v, ok
+	case map[interface{}]interface{}:
+		v, ok := value[key]
+		return v, ok
+	case Selector:
+		v, err := value.SelectGVal(c, key)
+		return v, err == nil
+	}
+	vv := reflect.ValueOf(value)
+	if policy.blocks(vv.Type()) {
+		return nil, false
+	}
+	vv = resolvePotentialPointer(vv)
+	switch vv.Kind() {
+	case reflect.Map:
+		mapKey, ok := reflectConvertTo(vv.Type().Key().Kind(), key)
+		if !ok {
+			return nil, false
+		}
+		v := vv.MapIndex(reflect.ValueOf(mapKey))
+		if !v.IsValid() {
+			return nil, false
+		}
+		return policy.selection(v)
+	case reflect.Struct:
+		field := vv.FieldByName(key)
+		if !field.IsValid() || !field.CanInterface() {
+			return nil, false
+		}
+		return policy.selection(field)
+	}
+	return nil, false
+}
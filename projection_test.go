@@ -0,0 +1,57 @@
+package gval
+
+import (
+	"testing"
+)
+
+type lineItem struct {
+	ID    string
+	Price float64
+	Qty   int
+	Tags  []string
+	note  string
+}
+
+type cart struct {
+	ID    string
+	Items []*lineItem
+}
+
+func TestProjection(t *testing.T) {
+	payload := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "a", "price": 2., "qty": 3.},
+			map[string]interface{}{"id": "b", "price": 5., "qty": 0.},
+			map[string]interface{}{"id": "c", "price": 1., "qty": 2., "parts": []interface{}{map[string]interface{}{"id": "c1"}}},
+		},
+		"stock": map[string]interface{}{"y": 2., "x": 1.},
+		"cart": cart{ID: "cart", Items: []*lineItem{
+			{ID: "p", Price: 1.5, Qty: 2, Tags: []string{"new"}},
+			{ID: "q", Price: 3, Qty: 1, note: "hidden"},
+		}},
+		"min": 1.,
+	}
+	cyclic := map[string]interface{}{"id": "root"}
+	cyclic["children"] = []interface{}{cyclic, map[string]interface{}{"id": "child", "parent": cyclic}}
+	testEvaluate([]evaluationTest{
+		{name: "wildcard", expression: `items[*].id`, parameter: payload, want: []interface{}{"a", "b", "c"}},
+		{name: "wildcard map", expression: `stock[*]`, parameter: payload, want: []interface{}{1., 2.}},
+		{name: "wildcard reflected", expression: `cart.Items[*].Price`, parameter: payload, want: []interface{}{1.5, 3.}},
+		{name: "nested wildcards are flattened", expression: `cart.Items[*].Tags[*]`, parameter: payload, want: []interface{}{"new"}},
+		{name: "wildcard on number", expression: `min[*]`, parameter: payload, wantErr: "can not select [*] on float64"},
+		{name: "filter", expression: `items[? @.qty > 0].id`, parameter: payload, want: []interface{}{"a", "c"}},
+		{name: "filter with parameter", expression: `items[? @.price > min].id`, parameter: payload, want: []interface{}{"a", "b"}},
+		{name: "filter reflected id", expression: `cart.Items[? @.Qty == 1].ID`, parameter: payload, want: []interface{}{"q"}},
+		{name: "filter not bool", expression: `items[? @.id].qty`, parameter: payload, wantErr: "filter expects a bool but got string"},
+		{name: "@ outside filter", expression: `@.id`, parameter: payload, wantErr: "@ is only defined in filters"},
+		{name: "recursive", expression: `items..id`, parameter: payload, want: []interface{}{"a", "b", "c", "c1"}},
+		{name: "recursive root", expression: `..ID`, parameter: payload, want: []interface{}{"cart", "p", "q"}},
+		{name: "recursive unexported field", expression: `..note`, parameter: payload, want: []interface{}{}},
+		{name: "recursive cyclic map", expression: `..id`, parameter: cyclic, want: []interface{}{"root", "child"}},
+		{name: "in projection", expression: `"b" in items[*].id`, parameter: payload, want: true},
+		{name: "sum projection", expression: `sum(items[*].price)`, extension: Aggregations(), parameter: payload, want: 8.},
+		{name: "count filter", expression: `count(items[? @.qty > 0])`, extension: Aggregations(), parameter: payload, want: 2.},
+		{name: "index", expression: `items[1].id`, parameter: payload, want: "b"},
+		{name: "multiplication in key", expression: `items[1*2].id`, parameter: payload, want: "c"},
+	}, t)
+}
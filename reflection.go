@@ -0,0 +1,108 @@
+package gval
+
+import (
+	"reflect"
+)
+
+// ReflectionPolicy restricts the access of the default variable selector to
+// fields, elements and methods of Go values via reflection.
+// Unexported fields are never accessible.
+// Values implementing Selector and the maps and slices of JSON are not affected.
+type ReflectionPolicy struct {
+	// DisableMethods disallows all method calls.
+	DisableMethods bool
+	// AllowedMethods lists the callable methods by receiver type.
+	// If AllowedMethods is not nil or TaggedMethods is true,
+	// other methods can not be called.
+	AllowedMethods map[reflect.Type][]string
+	// TaggedMethods allows the methods listed by MethodExposer.
+	TaggedMethods bool
+	// BlockedTypes can not be selected and nothing can be selected on them.
+	// Interface types block all types that implement them.
+	BlockedTypes []reflect.Type
+}
+
+// MethodExposer is implemented by types that expose methods to expressions
+// if the ReflectionPolicy allows TaggedMethods.
+type MethodExposer interface {
+	GValMethods() []string
+}
+
+// RestrictReflection returns a Language whose default variable selector
+// applies the ReflectionPolicy.
+//
+//	gval.RestrictReflection(gval.ReflectionPolicy{
+//		AllowedMethods: map[reflect.Type][]string{reflect.TypeOf(time.Time{}): {"Year", "Month"}},
+//		BlockedTypes:   []reflect.Type{reflect.TypeOf(&sql.DB{})},
+//	})
+func RestrictReflection(policy ReflectionPolicy) Language {
+	l := newLanguage()
+	l.reflectionPolicy = &policy
+	return l
+}
+
+// blocks reports if t is a blocked type or a pointer to a blocked type.
+func (policy *ReflectionPolicy) blocks(t reflect.Type) bool {
+	if policy == nil {
+		return false
+	}
+	for _, blocked := range policy.BlockedTypes {
+		if t == blocked || (t.Kind() == reflect.Ptr && t.Elem() == blocked) {
+			return true
+		}
+		if blocked.Kind() == reflect.Interface && t.Implements(blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// selection returns the selected value unless its type is blocked.
+func (policy *ReflectionPolicy) selection(v reflect.Value) (interface{}, bool) {
+	if v.IsValid() && policy.blocks(v.Type()) {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// method returns the method of v with given name if the policy allows it.
+func (policy *ReflectionPolicy) method(v reflect.Value, name string) (interface{}, bool) {
+	method := v.MethodByName(name)
+	if !method.IsValid() || !policy.allowsMethod(v, name) {
+		return nil, false
+	}
+	return method.Interface(), true
+}
+
+func (policy *ReflectionPolicy) allowsMethod(v reflect.Value, name string) bool {
+	switch {
+	case policy == nil:
+		return true
+	case policy.DisableMethods:
+		return false
+	case policy.AllowedMethods == nil && !policy.TaggedMethods:
+		return true
+	}
+	t := v.Type()
+	for _, allowed := range [][]string{policy.AllowedMethods[t], policy.AllowedMethods[reflect.PtrTo(t)]} {
+		if containsString(allowed, name) {
+			return true
+		}
+	}
+	if t.Kind() == reflect.Ptr && containsString(policy.AllowedMethods[t.Elem()], name) {
+		return true
+	}
+	if exposer, ok := v.Interface().(MethodExposer); ok && policy.TaggedMethods {
+		return containsString(exposer.GValMethods(), name)
+	}
+	return false
+}
+
+func containsString(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package gval
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type reflectionAccount struct {
+	Name     string
+	balance  float64
+	Database *reflectionDatabase
+	Secret   reflectionSecret
+}
+
+func (a reflectionAccount) Greet() string { return "hello " + a.Name }
+
+func (a reflectionAccount) Delete() string { return "deleted" }
+
+type reflectionDatabase struct {
+	Name string
+}
+
+func (*reflectionDatabase) Exec(query string) string { return "executed " + query }
+
+type reflectionSecret struct {
+	Key string
+}
+
+type reflectionExposer struct{}
+
+func (reflectionExposer) GValMethods() []string { return []string{"Visible"} }
+
+func (reflectionExposer) Visible() string { return "visible" }
+
+func (reflectionExposer) Hidden() string { return "hidden" }
+
+func TestRestrictReflection(t *testing.T) {
+	account := reflectionAccount{
+		Name:     "a",
+		balance:  10,
+		Database: &reflectionDatabase{Name: "db"},
+		Secret:   reflectionSecret{Key: "k"},
+	}
+	parameter := map[string]interface{}{"account": account, "exposer": reflectionExposer{}}
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "unexported field",
+				expression: `account.balance`,
+				parameter:  parameter,
+				wantErr:    "unknown parameter 'balance'",
+			},
+			{
+				name:       "method without policy",
+				expression: `account.Database.Exec("drop")`,
+				parameter:  parameter,
+				want:       "executed drop",
+			},
+			{
+				name:       "disabled methods",
+				expression: `account.Greet()`,
+				extension:  RestrictReflection(ReflectionPolicy{DisableMethods: true}),
+				parameter:  parameter,
+				wantErr:    "unknown parameter 'Greet'",
+			},
+			{
+				name:       "field with disabled methods",
+				expression: `account.Name`,
+				extension:  RestrictReflection(ReflectionPolicy{DisableMethods: true}),
+				parameter:  parameter,
+				want:       "a",
+			},
+			{
+				name:       "allowed method",
+				expression: `account.Greet()`,
+				extension: RestrictReflection(ReflectionPolicy{
+					AllowedMethods: map[reflect.Type][]string{reflect.TypeOf(reflectionAccount{}): {"Greet"}},
+				}),
+				parameter: parameter,
+				want:      "hello a",
+			},
+			{
+				name:       "not allowed method",
+				expression: `account.Delete()`,
+				extension: RestrictReflection(ReflectionPolicy{
+					AllowedMethods: map[reflect.Type][]string{reflect.TypeOf(reflectionAccount{}): {"Greet"}},
+				}),
+				parameter: parameter,
+				wantErr:   "unknown parameter 'Delete'",
+			},
+			{
+				name:       "allowed pointer method by element type",
+				expression: `account.Database.Exec("select")`,
+				extension: RestrictReflection(ReflectionPolicy{
+					AllowedMethods: map[reflect.Type][]string{reflect.TypeOf(reflectionDatabase{}): {"Exec"}},
+				}),
+				parameter: parameter,
+				want:      "executed select",
+			},
+			{
+				name:       "tagged method",
+				expression: `exposer.Visible()`,
+				extension:  RestrictReflection(ReflectionPolicy{TaggedMethods: true}),
+				parameter:  parameter,
+				want:       "visible",
+			},
+			{
+				name:       "untagged method",
+				expression: `exposer.Hidden()`,
+				extension:  RestrictReflection(ReflectionPolicy{TaggedMethods: true}),
+				parameter:  parameter,
+				wantErr:    "unknown parameter 'Hidden'",
+			},
+			{
+				name:       "blocked type",
+				expression: `account.Database.Exec("drop")`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf(reflectionDatabase{})},
+				}),
+				parameter: parameter,
+				wantErr:   "unknown parameter 'Database'",
+			},
+			{
+				name:       "blocked field type",
+				expression: `account.Secret.Key`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf(reflectionSecret{})},
+				}),
+				parameter: parameter,
+				wantErr:   "unknown parameter 'Secret'",
+			},
+			{
+				name:       "blocked pointer type in map",
+				expression: `databases.main.Name`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf(&reflectionDatabase{})},
+				}),
+				parameter: map[string]interface{}{"databases": map[string]*reflectionDatabase{"main": account.Database}},
+				wantErr:   "unknown parameter 'main'",
+			},
+			{
+				name:       "blocked pointer type in slice",
+				expression: `databases[0].Name`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf(&reflectionDatabase{})},
+				}),
+				parameter: map[string]interface{}{"databases": []*reflectionDatabase{account.Database}},
+				wantErr:   "unknown parameter '0'",
+			},
+			{
+				name:       "blocked interface",
+				expression: `account.Name`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), reflect.TypeOf((*MethodExposer)(nil)).Elem()},
+				}),
+				parameter: map[string]interface{}{"account": account, "exposer": reflectionExposer{}},
+				want:      "a",
+			},
+			{
+				name:       "blocked implementation of interface",
+				expression: `exposer.Visible()`,
+				extension: RestrictReflection(ReflectionPolicy{
+					BlockedTypes: []reflect.Type{reflect.TypeOf((*MethodExposer)(nil)).Elem()},
+				}),
+				parameter: parameter,
+				wantErr:   "unknown parameter 'Visible'",
+			},
+		},
+		t,
+	)
+}
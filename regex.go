@@ -0,0 +1,185 @@
+package gval
+
+import (
+	"container/list"
+	"context"
+	"regexp"
+	"sync"
+)
+
+// Regex contains functions for regular expressions in the syntax of the regexp package.
+//
+//	match(s, pattern) is true iff s contains a match of pattern
+//	find(s, pattern) returns the first match or nil
+//	findAll(s, pattern) returns all matches as array
+//	capture(s, pattern) returns the named groups of the first match as object or nil
+//	regexReplace(s, pattern, replacement) replaces all matches, $1 or ${name} in replacement expand to groups
+//	regexSplit(s, pattern) splits s around the matches as array
+//
+// regexReplace and regexSplit are not called replace and split to not clash with the
+// functions of Strings that take plain strings, so both languages can be combined.
+//
+// Compiled patterns are cached in a bounded cache shared with the =~ and !~ operators.
+func Regex() Language {
+	return regex
+}
+
+var regex = NewLanguage(
+	Function("match", func(c context.Context, s, pattern string) (bool, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(s), nil
+	}, functionInfo("match reports whether s contains a match of pattern.", `match("abc", "b+")`, "s", "pattern")),
+
+	Function("find", func(c context.Context, s, pattern string) (interface{}, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return nil, err
+		}
+		loc := re.FindStringIndex(s)
+		if loc == nil {
+			return nil, nil
+		}
+		return s[loc[0]:loc[1]], nil
+	}, functionInfo("find returns the first match of pattern in s or nil.", `find("a1b22", "[0-9]+")`, "s", "pattern")),
+
+	Function("findAll", func(c context.Context, s, pattern string) ([]interface{}, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return stringsToInterfaces(re.FindAllString(s, -1)), nil
+	}, functionInfo("findAll returns all matches of pattern in s.", `findAll("a1b22", "[0-9]+")`, "s", "pattern")),
+
+	Function("capture", func(c context.Context, s, pattern string) (map[string]interface{}, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return nil, err
+		}
+		match := re.FindStringSubmatch(s)
+		if match == nil {
+			return nil, nil
+		}
+		groups := map[string]interface{}{}
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				groups[name] = match[i]
+			}
+		}
+		return groups, nil
+	}, functionInfo("capture returns the named groups of the first match of pattern in s or nil.",
+		`capture("user=bob", "user=(?P<user>\\w+)")`, "s", "pattern")),
+
+	Function("regexReplace", func(c context.Context, s, pattern, replacement string) (string, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return "", err
+		}
+		return re.ReplaceAllString(s, replacement), nil
+	}, functionInfo("regexReplace replaces all matches of pattern in s. $1 or ${name} in replacement expand to groups.",
+		`regexReplace("a1b2", "[0-9]", "<$0>")`, "s", "pattern", "replacement")),
+
+	Function("regexSplit", func(c context.Context, s, pattern string) ([]interface{}, error) {
+		re, err := compileRegexp(c, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return stringsToInterfaces(re.Split(s, -1)), nil
+	}, functionInfo("regexSplit splits s around the matches of pattern.", `regexSplit("a, b;c", "[,;] *")`, "s", "pattern")),
+)
+
+// functionInfo describes a pure function with named parameters.
+func functionInfo(description, example string, parameters ...string) FunctionInfo {
+	info := FunctionInfo{
+		Description: description,
+		Examples:    []string{example},
+		Pure:        true,
+	}
+	for _, name := range parameters {
+		info.Parameters = append(info.Parameters, ParameterInfo{Name: name})
+	}
+	return info
+}
+
+func stringsToInterfaces(strs []string) []interface{} {
+	values := make([]interface{}, len(strs))
+	for i, s := range strs {
+		values[i] = s
+	}
+	return values
+}
+
+// compileRegexp returns the cached compiled pattern and checks its size against the budget of the evaluation.
+func compileRegexp(c context.Context, pattern string) (*regexp.Regexp, error) {
+	re, err := regexps.compile(pattern, func(size func() int) error {
+		return checkRegexpSize(c, size)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return re.Regexp, nil
+}
+
+// regexps caches compiled patterns of non constant regular expressions.
+var regexps = newRegexpCache(256)
+
+type cachedRegexp struct {
+	*regexp.Regexp
+	pattern string
+	size    func() int
+}
+
+// regexpCache is a least recently used cache of compiled regular expressions.
+type regexpCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func newRegexpCache(capacity int) *regexpCache {
+	return &regexpCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// compile returns the cached compiled pattern. check is called with the size of the pattern
+// before a pattern is returned or compiled.
+func (rc *regexpCache) compile(pattern string, check func(size func() int) error) (*cachedRegexp, error) {
+	rc.mu.Lock()
+	if e, ok := rc.entries[pattern]; ok {
+		rc.order.MoveToFront(e)
+		rc.mu.Unlock()
+		cached := e.Value.(*cachedRegexp)
+		return cached, check(cached.size)
+	}
+	rc.mu.Unlock()
+
+	size := lazyRegexpSize(pattern)
+	if err := check(size); err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedRegexp{Regexp: re, pattern: pattern, size: size}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if e, ok := rc.entries[pattern]; ok {
+		rc.order.MoveToFront(e)
+		return e.Value.(*cachedRegexp), nil
+	}
+	rc.entries[pattern] = rc.order.PushFront(cached)
+	for rc.order.Len() > rc.capacity {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cachedRegexp).pattern)
+	}
+	return cached, nil
+}
@@ -0,0 +1,136 @@
+package gval
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "match",
+				expression: `match("abc", "b+")`,
+				extension:  Regex(),
+				want:       true,
+			},
+			{
+				name:       "no match",
+				expression: `match("abc", "d")`,
+				extension:  Regex(),
+				want:       false,
+			},
+			{
+				name:       "find",
+				expression: `find("a1b22", "[0-9]{2}")`,
+				extension:  Regex(),
+				want:       "22",
+			},
+			{
+				name:       "find nothing",
+				expression: `find("abc", "[0-9]")`,
+				extension:  Regex(),
+				want:       nil,
+			},
+			{
+				name:       "findAll",
+				expression: `findAll(s, "[0-9]+")`,
+				extension:  Regex(),
+				parameter:  map[string]interface{}{"s": "a1b22c333"},
+				want:       []interface{}{"1", "22", "333"},
+			},
+			{
+				name:       "capture",
+				expression: `capture(msg, "user=(?P<user>\\w+) id=(?P<id>\\d+)")`,
+				extension:  Regex(),
+				parameter:  map[string]interface{}{"msg": "login user=bob id=42"},
+				want:       map[string]interface{}{"user": "bob", "id": "42"},
+			},
+			{
+				name:       "capture nothing",
+				expression: `capture("abc", "(?P<digit>[0-9])")`,
+				extension:  Regex(),
+				want:       map[string]interface{}(nil),
+			},
+			{
+				name:       "regexReplace",
+				expression: `regexReplace("a1b22", "(?P<n>[0-9]+)", "<${n}>")`,
+				extension:  Regex(),
+				want:       "a<1>b<22>",
+			},
+			{
+				name:       "regexSplit",
+				expression: `regexSplit("a, b;c", "[,;] *")`,
+				extension:  Regex(),
+				want:       []interface{}{"a", "b", "c"},
+			},
+			{
+				name:       "invalid pattern",
+				expression: `match(s, "(")`,
+				extension:  Regex(),
+				parameter:  map[string]interface{}{"s": "a"},
+				wantErr:    "missing closing )",
+			},
+			{
+				name:       "module",
+				expression: `re.regexReplace("a1", "[0-9]", "x")`,
+				extension:  Module("re", Regex()),
+				want:       "ax",
+			},
+			{
+				name:       "non constant regex operator",
+				expression: `s =~ pattern && !(s !~ pattern)`,
+				parameter:  map[string]interface{}{"s": "abc", "pattern": "^a"},
+				want:       true,
+			},
+		},
+		t,
+	)
+}
+
+func TestRegex_pure(t *testing.T) {
+	eval, err := NewLanguage(Full(), Regex()).NewEvaluable(`find("a1", "[0-9]")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eval.IsConst() {
+		t.Errorf("find with constant arguments is not constant")
+	}
+}
+
+func Test_regexpCache(t *testing.T) {
+	rc := newRegexpCache(2)
+	unchecked := func(func() int) error { return nil }
+	compile := func(pattern string) *cachedRegexp {
+		re, err := rc.compile(pattern, unchecked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return re
+	}
+	a := compile("a")
+	compile("b")
+	if compile("a") != a {
+		t.Errorf("cached pattern a was compiled again")
+	}
+	compile("c")
+	if _, ok := rc.entries["b"]; ok {
+		t.Errorf("least recently used pattern b was not evicted")
+	}
+	if compile("a") != a {
+		t.Errorf("recently used pattern a was evicted")
+	}
+	if rc.order.Len() != 2 || len(rc.entries) != 2 {
+		t.Errorf("cache has %d entries, want 2", rc.order.Len())
+	}
+	if _, err := rc.compile("(", unchecked); err == nil {
+		t.Errorf("invalid pattern expected error")
+	}
+	exceeded := fmt.Errorf("exceeded")
+	if _, err := rc.compile("d", func(func() int) error { return exceeded }); err != exceeded {
+		t.Errorf("compile() error = %v, want %v", err, exceeded)
+	}
+	if _, ok := rc.entries["d"]; ok {
+		t.Errorf("pattern d exceeding the check was compiled")
+	}
+}
@@ -0,0 +1,85 @@
+package gval
+
+// Filter returns a copy of the Language with the functions, constants, prefix
+// extensions and operators for which keep returns true.
+// Precedences without operator and the other settings of the Language are kept.
+//
+// Filter can be used to derive restricted Languages, e.g. per customer tier:
+//
+//	gval.Full().Filter(func(e gval.Element) bool { return e.Kind != gval.FunctionElement })
+func (l Language) Filter(keep func(Element) bool) Language {
+	filtered := l
+	filtered.prefixes = map[interface{}]extension{}
+	filtered.operators = map[string]operator{}
+	filtered.operatorSymbols = map[rune]struct{}{}
+	filtered.overloads = map[string]overloads{}
+	filtered.elements = map[interface{}]Element{}
+
+	for key, ext := range l.prefixes {
+		element, ok := l.elements[key]
+		if !ok {
+			element = Element{Kind: PrefixExtensionElement, Name: prefixName(key)}
+		}
+		if element.Kind == FunctionElement {
+			element.Functions = l.functions(element.Name)
+		}
+		if !keep(element) {
+			continue
+		}
+		filtered.prefixes[key] = ext
+		if ok {
+			filtered.elements[key] = l.elements[key]
+		}
+		if o, ok := l.overloads[element.Name]; ok && element.Kind == FunctionElement {
+			filtered.overloads[element.Name] = o
+		}
+	}
+	for name, op := range l.operators {
+		if element, ok := operatorElement(name, op); ok && !keep(element) {
+			continue
+		}
+		filtered.operators[name] = op
+	}
+	for r := range l.operatorSymbols {
+		filtered.operatorSymbols[r] = struct{}{}
+	}
+	return filtered
+}
+
+// Without returns a copy of the Language without the elements with given names.
+// A name removes every element with that name, e.g. "-" removes the prefix and the infix operator.
+// Prefix extensions are named as in Language.Elements.
+//
+//	gval.Full().Without("=~", "!~", "date")
+func (l Language) Without(names ...string) Language {
+	remove := nameSet(names)
+	return l.Filter(func(e Element) bool {
+		_, removed := remove[e.Name]
+		return !removed
+	})
+}
+
+// Only returns a copy of the Language that contains only the functions,
+// constants and operators with given names.
+// Prefix extensions like literals, parentheses, arrays, objects, identifiers
+// or keywords are kept. Use Without to remove them.
+//
+//	gval.Full().Only("+", "-", "*", "/", "true", "false")
+func (l Language) Only(names ...string) Language {
+	allow := nameSet(names)
+	return l.Filter(func(e Element) bool {
+		if e.Kind == PrefixExtensionElement {
+			return true
+		}
+		_, allowed := allow[e.Name]
+		return allowed
+	})
+}
+
+func nameSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,145 @@
+package gval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testLanguageEvaluate evaluates the tests with their extension as Language instead of extending Full.
+func testLanguageEvaluate(tests []evaluationTest, t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.extension.Evaluate(tt.expression, tt.parameter)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Evaluate(%s) = %v, %v want error %s", tt.expression, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate(%s) error = %v", tt.expression, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate(%s) = %v, want %v", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguage_Without(t *testing.T) {
+	testLanguageEvaluate(
+		[]evaluationTest{
+			{
+				name:       "removed infix operator",
+				expression: `"abc" =~ "b"`,
+				extension:  Full().Without("=~"),
+				wantErr:    `unexpected "=" while scanning operator`,
+			},
+			{
+				name:       "kept infix operator",
+				expression: `"abc" !~ "d"`,
+				extension:  Full().Without("=~"),
+				want:       true,
+			},
+			{
+				name:       "removed prefix and infix operator",
+				expression: `-1`,
+				extension:  Full().Without("-"),
+				wantErr:    "unexpected",
+			},
+			{
+				name:       "removed function",
+				expression: `date("2020-01-01")`,
+				extension:  Full().Without("date"),
+				wantErr:    "unknown parameter 'date'",
+			},
+			{
+				name:       "removed constant",
+				expression: `true`,
+				extension:  Full().Without("true"),
+				wantErr:    "unknown parameter 'true'",
+			},
+			{
+				name:       "removed prefix extension",
+				expression: `{"a": 1}`,
+				extension:  Full().Without("{"),
+				wantErr:    "unexpected",
+			},
+			{
+				name:       "removed postfix operator",
+				expression: `true ? 1 : 2`,
+				extension:  Full().Without("?"),
+				wantErr:    `unexpected "?" while scanning operator`,
+			},
+			{
+				name:       "composed",
+				expression: `f(1) + 1`,
+				extension:  NewLanguage(Full().Without("date"), Function("f", func(x float64) float64 { return x })),
+				want:       2.,
+			},
+			{
+				name:       "function added after removal",
+				expression: `date()`,
+				extension:  NewLanguage(Full().Without("date"), Function("date", func() string { return "today" })),
+				want:       "today",
+			},
+		},
+		t,
+	)
+}
+
+func TestLanguage_Only(t *testing.T) {
+	tier := Full().Only("+", "*", "true")
+	testLanguageEvaluate(
+		[]evaluationTest{
+			{
+				name:       "allowed",
+				expression: `(1 + 2) * x`,
+				extension:  tier,
+				parameter:  map[string]interface{}{"x": 2},
+				want:       6.,
+			},
+			{
+				name:       "literals",
+				expression: `[1, "a", {"b": true}]`,
+				extension:  tier,
+				want:       []interface{}{1., "a", map[string]interface{}{"b": true}},
+			},
+			{
+				name:       "not allowed operator",
+				expression: `1 - 2`,
+				extension:  tier,
+				wantErr:    `unexpected "-" while scanning operator`,
+			},
+			{
+				name:       "not allowed constant",
+				expression: `false`,
+				extension:  tier,
+				wantErr:    "unknown parameter 'false'",
+			},
+			{
+				name:       "not allowed function",
+				expression: `date("2020-01-01")`,
+				extension:  tier,
+				wantErr:    "unknown parameter 'date'",
+			},
+		},
+		t,
+	)
+}
+
+func TestLanguage_Filter(t *testing.T) {
+	lang := Full().Filter(func(e Element) bool { return e.Kind != FunctionElement })
+	if fs := lang.Functions(); len(fs) != 0 {
+		t.Errorf("Functions() = %v, want none", fs)
+	}
+	if fs := Full().Functions(); len(fs) != 1 {
+		t.Errorf("Filter changed the original Language: Functions() = %v", fs)
+	}
+	for _, e := range lang.Elements() {
+		if e.Kind == FunctionElement {
+			t.Errorf("Elements() contains %v", e)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"text/scanner"
+)
+
+// Script returns a Language that parses a sequence of statements instead of
+// a single expression. The result of a script is the value of its last statement.
+//
+// Statements are separated by ';' or by a line break. A line break ends a
+// statement unless it follows an operator or is inside brackets or a ternary,
+// e.g. "1\n-2" are two statements while "1 -\n2" and "(1\n-2)" are one.
+//
+//	let name = expression binds the value of expression to name
+//
+// All statements share one scope. A bound name shadows a parameter of the
+// same name for all following statements. Binding a name again replaces its value.
+// Script reserves the word let and must be combined with a Language
+// for the expressions, e.g. gval.Full(gval.Script()).
+func Script() Language {
+	return script
+}
+
+var script = Init(parseScript)
+
+func parseScript(c context.Context, p *Parser) (Evaluable, error) {
+	p.newlineSeparated = true
+	statements := []Evaluable{}
+	var bindings []*binding
+	for {
+		switch p.Scan() {
+		case scanner.EOF:
+			return newScript(statements, bindings)
+		case ';':
+			continue
+		}
+		p.Camouflage("script")
+
+		statement, b, err := parseStatement(c, p)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+		if b != nil {
+			bindings = append(bindings, b)
+		}
+
+		switch p.Scan() {
+		case ';', scanner.EOF:
+		default:
+			if !p.isNewline() {
+				return nil, p.Expected("script", ';', scanner.EOF)
+			}
+		}
+		p.Camouflage("script")
+	}
+}
+
+// parseStatement parses a let statement or an expression. It returns the
+// binding of a let statement if the binding is new.
+func parseStatement(c context.Context, p *Parser) (Evaluable, *binding, error) {
+	if p.Scan() != scanner.Ident || p.TokenText() != "let" {
+		p.Camouflage("statement")
+		eval, err := p.ParseExpression(c)
+		return eval, nil, err
+	}
+	if p.Scan() != scanner.Ident {
+		return nil, nil, p.Expected("let", scanner.Ident)
+	}
+	name := p.TokenText()
+	if _, ok := p.prefixes[name]; ok {
+		return nil, nil, fmt.Errorf("can not bind %s: name is already defined", name)
+	}
+	if p.Scan() != '=' {
+		return nil, nil, p.Expected("let", '=')
+	}
+	eval, err := p.ParseExpression(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, ok := p.bindings[name]
+	if ok {
+		return b.assign(eval), nil, nil
+	}
+	b = &binding{name: name}
+	if p.bindings == nil {
+		p.bindings = map[string]*binding{}
+	}
+	p.bindings[name] = b
+	return b.assign(eval), b, nil
+}
+
+func newScript(statements []Evaluable, bindings []*binding) (Evaluable, error) {
+	switch {
+	case len(statements) == 0:
+		return nil, fmt.Errorf("script has no statement")
+	case len(statements) == 1 && len(bindings) == 0:
+		return statements[0], nil
+	}
+	return func(c context.Context, v interface{}) (r interface{}, err error) {
+		for _, b := range bindings {
+			c = b.bind(c, nil)
+		}
+		for _, statement := range statements {
+			r, err = statement(c, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}, nil
+}
+
+// binding is a name that refers to a value in the evaluation context
+// instead of the parameter.
+type binding struct {
+	name string
+}
+
+type bindingValue struct {
+	value interface{}
+}
+
+// bind returns a context in which b has the given value.
+func (b *binding) bind(c context.Context, value interface{}) context.Context {
+	return context.WithValue(c, b, &bindingValue{value})
+}
+
+// get is an Evaluable that returns the value of b.
+func (b *binding) get(c context.Context, v interface{}) (interface{}, error) {
+	bv, err := b.value(c)
+	if err != nil {
+		return nil, err
+	}
+	return bv.value, nil
+}
+
+func (b *binding) value(c context.Context) (*bindingValue, error) {
+	if c != nil {
+		if v, ok := c.Value(b).(*bindingValue); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not bound", b.name)
+}
+
+func (b *binding) assign(eval Evaluable) Evaluable {
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		x, err := eval(c, v)
+		if err != nil {
+			return nil, err
+		}
+		bv, err := b.value(c)
+		if err != nil {
+			return nil, err
+		}
+		bv.value = x
+		return x, nil
+	}
+}
+
+// bindScoped binds name until unbind is called. unbind restores a shadowed binding.
+func (p *Parser) bindScoped(name string) (b *binding, unbind func()) {
+	b = &binding{name: name}
+	outer, shadowed := p.bindings[name]
+	if p.bindings == nil {
+		p.bindings = map[string]*binding{}
+	}
+	p.bindings[name] = b
+	return b, func() {
+		if shadowed {
+			p.bindings[name] = outer
+		} else {
+			delete(p.bindings, name)
+		}
+	}
+}
+
+// variable selects path on the value of b. It behaves like Var if b is nil.
+func (p *Parser) variable(b *binding, path Evaluables) Evaluable {
+	if b == nil {
+		return p.Var(path...)
+	}
+	path = path[1:]
+	policy := p.reflectionPolicy
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		x, err := b.get(c, v)
+		if err != nil {
+			return nil, err
+		}
+		return selectPath(c, v, x, path, policy)
+	}
+}
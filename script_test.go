@@ -0,0 +1,154 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "single expression",
+				expression: "1 + 2",
+				extension:  Script(),
+				want:       3.,
+			},
+			{
+				name:       "semicolon separated",
+				expression: "1 + 2; 3 + 4",
+				extension:  Script(),
+				want:       7.,
+			},
+			{
+				name:       "newline separated",
+				expression: "1 + 2\n3 + 4",
+				extension:  Script(),
+				want:       7.,
+			},
+			{
+				name:       "trailing semicolon",
+				expression: "1; 2;\n",
+				extension:  Script(),
+				want:       2.,
+			},
+			{
+				name:       "operator continues line",
+				expression: "1 +\n2",
+				extension:  Script(),
+				want:       3.,
+			},
+			{
+				name:       "operator on next line",
+				expression: "1\n-2",
+				extension:  Script(),
+				want:       -2.,
+			},
+			{
+				name:       "newline in parentheses",
+				expression: "(1\n+ 2)",
+				extension:  Script(),
+				want:       3.,
+			},
+			{
+				name:       "newline in ternary",
+				expression: "true\n? 1\n: 2",
+				extension:  Script(),
+				want:       1.,
+			},
+			{
+				name:       "newline after ternary",
+				expression: "false ? 1 : 2\n-3",
+				extension:  Script(),
+				want:       -3.,
+			},
+			{
+				name:       "newline in brackets",
+				expression: "let a = [1\n, 2\n]\nlet o = {\"b\": 3\n, \"c\": 4}\na[1\n] + o.c",
+				extension:  Script(),
+				want:       6.,
+			},
+			{
+				name:       "newline in arguments",
+				expression: "foo.Nested.Dunk(\n\"a\"\n)",
+				extension:  Script(),
+				parameter:  map[string]interface{}{"foo": foo},
+				want:       "adunk",
+			},
+			{
+				name:       "let",
+				expression: "let a = 2\nlet b = a * 3\na + b",
+				extension:  Script(),
+				want:       8.,
+			},
+			{
+				name:       "let result",
+				expression: "let a = 2",
+				extension:  Script(),
+				want:       2.,
+			},
+			{
+				name:       "rebind",
+				expression: "let a = 2; let a = a + 1; a",
+				extension:  Script(),
+				want:       3.,
+			},
+			{
+				name:       "shadow parameter",
+				expression: "let a = a * 10; a",
+				extension:  Script(),
+				parameter:  map[string]interface{}{"a": 4},
+				want:       40.,
+			},
+			{
+				name:       "select on binding",
+				expression: "let o = {\"a\": [1, x]}\no.a[1]",
+				extension:  Script(),
+				parameter:  map[string]interface{}{"x": "y"},
+				want:       "y",
+			},
+			{
+				name:       "call on binding",
+				expression: "let f = foo.Nested\nf.Dunk(\"a\")",
+				extension:  Script(),
+				parameter:  map[string]interface{}{"foo": foo},
+				want:       "adunk",
+			},
+			{
+				name:       "multiline argument list",
+				expression: "let a = [\n1,\n2\n]\na[1]",
+				extension:  Script(),
+				want:       2.,
+			},
+			{
+				name:       "missing separator",
+				expression: "1 2",
+				extension:  Script(),
+				wantErr:    "unexpected Int while scanning script expected \";\" or EOF",
+			},
+			{
+				name:       "empty",
+				expression: ";",
+				extension:  Script(),
+				wantErr:    "script has no statement",
+			},
+			{
+				name:       "bind constant",
+				expression: "let true = 1",
+				extension:  Script(),
+				wantErr:    "can not bind true",
+			},
+			{
+				name:       "let without assignment",
+				expression: "let a 1",
+				extension:  Script(),
+				wantErr:    "unexpected Int while scanning let expected \"=\"",
+			},
+			{
+				name:       "no script",
+				expression: "1; 2",
+				wantErr:    unexpected(`";"`, "operator"),
+			},
+		},
+		t,
+	)
+}
@@ -0,0 +1,371 @@
+package gval
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Semver contains semantic versions as described by https://semver.org.
+//
+//	semver(s) parses s into a Version, a leading v is ignored
+//	versionIn(v, constraint) reports if the version v satisfies the constraint
+//
+// Version implements Comparable, the comparison operators of Semver order versions by precedence.
+// If one operand is a Version a string operand is parsed as version, e.g. semver(app.version) >= "2.3.0-beta.1".
+//
+// Constraints combine comparisons of versions like ">=1.2.3 <2" with AND by space and
+// with OR by ||. Missing parts of versions and the parts x, X and * match any number.
+// Caret ranges ^1.2.3 allow changes that do not modify the left-most non-zero part,
+// tilde ranges ~1.2.3 allow patch changes and hyphen ranges 1.2 - 1.4 include both ends.
+// A prerelease version only satisfies a constraint that contains a prerelease of the same
+// major, minor and patch version, e.g. 1.3.0-beta.2 satisfies ">=1.3.0-beta" but not "^1.2".
+func Semver() Language {
+	return semver
+}
+
+var semver = NewLanguage(
+	comparison,
+	Function("semver", func(v interface{}) (Version, error) {
+		return toVersion("semver", v)
+	}, FunctionInfo{
+		Parameters:  []ParameterInfo{{Name: "version", Type: reflect.TypeOf("")}},
+		Results:     []reflect.Type{reflect.TypeOf(Version{})},
+		Description: "semver parses a semantic version.",
+		Examples:    []string{`semver("2.3.0-beta.1")`, `semver(app.version) >= semver("2.3.0")`},
+		Pure:        true,
+	}),
+	Function("versionIn", func(v interface{}, constraint string) (bool, error) {
+		version, err := toVersion("versionIn", v)
+		if err != nil {
+			return false, err
+		}
+		r, err := parseVersionRange(constraint)
+		if err != nil {
+			return false, fmt.Errorf("versionIn() could not parse constraint %q: %w", constraint, err)
+		}
+		return r.contains(version), nil
+	}, FunctionInfo{
+		Parameters: []ParameterInfo{
+			{Name: "version", Type: reflect.TypeOf("")},
+			{Name: "constraint", Type: reflect.TypeOf("")},
+		},
+		Results:     []reflect.Type{reflect.TypeOf(false)},
+		Description: "versionIn reports if version satisfies the constraint.",
+		Examples:    []string{`versionIn(app.version, "^2.3")`, `versionIn(app.version, ">=1.2.0 <1.5.0 || >=2")`},
+		Pure:        true,
+	}),
+)
+
+// Version is a semantic version returned by semver(). It implements Comparable.
+type Version struct {
+	Major, Minor, Patch uint64
+	// Prerelease contains the dot separated identifiers after -.
+	Prerelease []string
+	// Build contains the dot separated identifiers after +. They are ignored for comparisons.
+	Build []string
+}
+
+// ParseVersion parses a semantic version. A leading v is ignored.
+func ParseVersion(s string) (Version, error) {
+	p, err := parsePartialVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if p.parts < 3 {
+		return Version{}, fmt.Errorf("invalid version %q: expected major.minor.patch", s)
+	}
+	return p.Version, nil
+}
+
+func toVersion(name string, v interface{}) (Version, error) {
+	switch v := v.(type) {
+	case Version:
+		return v, nil
+	case string:
+		version, err := ParseVersion(v)
+		if err != nil {
+			return Version{}, fmt.Errorf("%s() %w", name, err)
+		}
+		return version, nil
+	}
+	return Version{}, fmt.Errorf("%s() expects a version but got %T", name, v)
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns the order of the precedence of v and w.
+func (v Version) Compare(w Version) int {
+	for _, c := range [][2]uint64{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v.Prerelease) == 0 && len(w.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(w.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(w.Prerelease); i++ {
+		if c := comparePrerelease(v.Prerelease[i], w.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return len(v.Prerelease) - len(w.Prerelease)
+}
+
+// CompareGVal compares v with a Version or a string that is parsed as version.
+func (v Version) CompareGVal(other interface{}) (int, error) {
+	w, err := toVersion("compare", other)
+	if err != nil {
+		return 0, fmt.Errorf("can not compare version with %T", other)
+	}
+	return v.Compare(w), nil
+}
+
+// comparePrerelease compares numeric identifiers numerically and lower than alphanumeric identifiers.
+func comparePrerelease(a, b string) int {
+	x, errX := strconv.ParseUint(a, 10, 64)
+	y, errY := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errX == nil && errY == nil:
+		if x == y {
+			return 0
+		}
+		if x < y {
+			return -1
+		}
+		return 1
+	case errX == nil:
+		return -1
+	case errY == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// partialVersion is a version with the given number of specified parts.
+type partialVersion struct {
+	Version
+	parts int
+}
+
+func parsePartialVersion(s string) (partialVersion, error) {
+	p := partialVersion{}
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "v"), "=")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		p.Build = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		p.Prerelease = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+	}
+	for _, ids := range [][]string{p.Prerelease, p.Build} {
+		for _, id := range ids {
+			if id == "" || strings.TrimLeft(id, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+				return p, fmt.Errorf("invalid version %q: invalid identifier %q", s, id)
+			}
+		}
+	}
+	if rest == "" {
+		return p, fmt.Errorf("invalid version %q", s)
+	}
+	numbers := []*uint64{&p.Major, &p.Minor, &p.Patch}
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("invalid version %q: too many parts", s)
+	}
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil || (len(part) > 1 && part[0] == '0') {
+			return p, fmt.Errorf("invalid version %q: invalid number %q", s, part)
+		}
+		*numbers[i] = n
+		p.parts = i + 1
+	}
+	if p.parts < 3 && len(p.Prerelease) > 0 {
+		return p, fmt.Errorf("invalid version %q: prerelease of partial version", s)
+	}
+	return p, nil
+}
+
+// next returns the lowest version after all versions matching p.
+func (p partialVersion) next() Version {
+	switch p.parts {
+	case 0:
+		return Version{Major: ^uint64(0), Minor: ^uint64(0), Patch: ^uint64(0)}
+	case 1:
+		return Version{Major: p.Major + 1, Prerelease: []string{"0"}}
+	case 2:
+		return Version{Major: p.Major, Minor: p.Minor + 1, Prerelease: []string{"0"}}
+	}
+	return Version{Major: p.Major, Minor: p.Minor, Patch: p.Patch + 1, Prerelease: []string{"0"}}
+}
+
+func (p partialVersion) lowest() Version {
+	return Version{Major: p.Major, Minor: p.Minor, Patch: p.Patch, Prerelease: p.Prerelease}
+}
+
+type versionComparator struct {
+	operator string
+	version  Version
+}
+
+func (c versionComparator) holds(v Version) bool {
+	r := v.Compare(c.version)
+	switch c.operator {
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	}
+	return r == 0
+}
+
+// versionRange is a disjunction of conjunctions of comparators.
+type versionRange [][]versionComparator
+
+func (r versionRange) contains(v Version) bool {
+	for _, set := range r {
+		if satisfies(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func satisfies(set []versionComparator, v Version) bool {
+	for _, c := range set {
+		if !c.holds(v) {
+			return false
+		}
+	}
+	if len(v.Prerelease) == 0 {
+		return true
+	}
+	for _, c := range set {
+		w := c.version
+		if len(w.Prerelease) > 0 && w.Major == v.Major && w.Minor == v.Minor && w.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func parseVersionRange(constraint string) (versionRange, error) {
+	r := versionRange{}
+	for _, alternative := range strings.Split(constraint, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 3 && fields[1] == "-" {
+			set, err := hyphenRange(fields[0], fields[2])
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, set)
+			continue
+		}
+		set := []versionComparator{}
+		for _, field := range fields {
+			comparators, err := parseVersionComparator(field)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, comparators...)
+		}
+		r = append(r, set)
+	}
+	return r, nil
+}
+
+func hyphenRange(from, to string) ([]versionComparator, error) {
+	lower, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, err
+	}
+	set := []versionComparator{{">=", lower.lowest()}}
+	if upper.parts == 3 {
+		return append(set, versionComparator{"<=", upper.lowest()}), nil
+	}
+	if upper.parts > 0 {
+		set = append(set, versionComparator{"<", upper.next()})
+	}
+	return set, nil
+}
+
+func parseVersionComparator(field string) ([]versionComparator, error) {
+	operator := ""
+	for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(field, op) {
+			operator = op
+			break
+		}
+	}
+	p, err := parsePartialVersion(field[len(operator):])
+	if err != nil {
+		return nil, err
+	}
+	lower := versionComparator{">=", p.lowest()}
+	switch operator {
+	case "^":
+		upper := p
+		switch {
+		case p.Major > 0 || p.parts < 2:
+			upper.parts = 1
+		case p.Minor > 0 || p.parts < 3:
+			upper.parts = 2
+		}
+		return []versionComparator{lower, {"<", upper.next()}}, nil
+	case "~":
+		upper := p
+		if p.parts > 2 {
+			upper.parts = 2
+		}
+		return []versionComparator{lower, {"<", upper.next()}}, nil
+	case ">":
+		if p.parts < 3 {
+			return []versionComparator{{">=", p.next()}}, nil
+		}
+	case "<=":
+		if p.parts < 3 {
+			return []versionComparator{{"<", p.next()}}, nil
+		}
+	case ">=", "<":
+	default:
+		if p.parts < 3 {
+			if p.parts == 0 {
+				return []versionComparator{lower}, nil
+			}
+			return []versionComparator{lower, {"<", p.next()}}, nil
+		}
+		operator = "="
+	}
+	return []versionComparator{{operator, p.lowest()}}, nil
+}
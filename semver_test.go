@@ -0,0 +1,60 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestSemver(t *testing.T) {
+	app := map[string]interface{}{"app": map[string]interface{}{"version": "2.3.1"}}
+	tests := []evaluationTest{
+		{name: "parse", expression: `semver("v1.2.3-beta.1+build.5")`, want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"beta", "1"}, Build: []string{"build", "5"}}},
+		{name: "parse partial", expression: `semver("1.2")`, wantErr: `invalid version "1.2": expected major.minor.patch`},
+		{name: "parse leading zero", expression: `semver("1.02.3")`, wantErr: `invalid number "02"`},
+		{name: "parse no string", expression: `semver(1)`, wantErr: "semver() expects a version but got float64"},
+		{name: "greater", expression: `semver(app.version) >= semver("2.3.0-beta.1")`, parameter: app, want: true},
+		{name: "minor numeric", expression: `semver("1.10.0") > semver("1.9.0")`, want: true},
+		{name: "prerelease lower", expression: `semver("1.0.0-alpha") < semver("1.0.0")`, want: true},
+		{name: "prerelease numeric", expression: `semver("1.0.0-beta.2") < semver("1.0.0-beta.11")`, want: true},
+		{name: "prerelease numeric before alphanumeric", expression: `semver("1.0.0-1") < semver("1.0.0-alpha")`, want: true},
+		{name: "prerelease shorter", expression: `semver("1.0.0-alpha") < semver("1.0.0-alpha.1")`, want: true},
+		{name: "string operand", expression: `semver(app.version) < "2.10.0"`, parameter: app, want: true},
+		{name: "equal ignores build", expression: `semver("1.0.0+a") == semver("1.0.0+b")`, want: true},
+		{name: "not equal", expression: `semver("1.0.0") != "1.0.1"`, want: true},
+		{name: "other equality", expression: `[1] == [1] && 1 == 1 && "a" != "b"`, want: true},
+		{name: "caret", expression: `versionIn(app.version, "^2.3")`, parameter: app, want: true},
+		{name: "caret major", expression: `versionIn("3.0.0", "^2.3")`, want: false},
+		{name: "caret zero minor", expression: `versionIn("0.3.0", "^0.2.3")`, want: false},
+		{name: "caret zero patch", expression: `versionIn("0.0.4", "^0.0.3")`, want: false},
+		{name: "tilde", expression: `versionIn("1.2.9", "~1.2.3")`, want: true},
+		{name: "tilde minor", expression: `versionIn("1.3.0", "~1.2.3")`, want: false},
+		{name: "range", expression: `versionIn("1.4.0", ">=1.2.0 <1.5.0")`, want: true},
+		{name: "range or", expression: `versionIn("2.1.0", ">=1.2.0 <1.5.0 || >=2")`, want: true},
+		{name: "x range", expression: `versionIn("1.2.7", "1.2.x") && !versionIn("1.3.0", "1.2.x")`, want: true},
+		{name: "any", expression: `versionIn("5.0.0", "*")`, want: true},
+		{name: "greater than partial", expression: `versionIn("1.9.9", ">1") || !versionIn("2.0.0", ">1")`, want: false},
+		{name: "less or equal partial", expression: `versionIn("1.2.9", "<=1.2") && !versionIn("1.3.0", "<=1.2")`, want: true},
+		{name: "hyphen", expression: `versionIn("1.4.5", "1.2 - 1.4") && !versionIn("1.5.0", "1.2 - 1.4")`, want: true},
+		{name: "hyphen full", expression: `versionIn("2.3.4", "1.2.3 - 2.3.4")`, want: true},
+		{name: "exact", expression: `versionIn("1.2.3", "=1.2.3") && !versionIn("1.2.4", "1.2.3")`, want: true},
+		{name: "prerelease excluded", expression: `versionIn("1.3.0-beta.2", "^1.2")`, want: false},
+		{name: "prerelease included", expression: `versionIn("1.3.0-beta.2", ">=1.3.0-beta")`, want: true},
+		{name: "prerelease other tuple", expression: `versionIn("1.4.0-beta", ">=1.3.0-beta")`, want: false},
+		{name: "invalid constraint", expression: `versionIn("1.0.0", ">=a")`, wantErr: `versionIn() could not parse constraint ">=a"`},
+	}
+	for i := range tests {
+		tests[i].extension = Semver()
+	}
+	testEvaluate(tests, t)
+}
+
+func TestSemver_combined(t *testing.T) {
+	parameter := map[string]interface{}{"arr": []int{1}, "v": "1.2.3"}
+	testEvaluate([]evaluationTest{
+		{name: "deep equality kept", expression: `[1] == arr`, extension: NewLanguage(DeepEquality(EqualityOptions{}), Semver()), parameter: parameter, want: true},
+		{name: "deep equality after semver", expression: `[1] == arr && semver(v) == "1.2.3"`, extension: NewLanguage(Semver(), DeepEquality(EqualityOptions{})), parameter: parameter, want: true},
+		{name: "versions with deep equality", expression: `[semver(v)] == ["1.2.3+build"]`, extension: NewLanguage(DeepEquality(EqualityOptions{}), Semver()), parameter: parameter, want: true},
+		{name: "text comparison kept", expression: `"a" < 1`, extension: Semver(), want: false},
+		{name: "number comparison kept", expression: `"10" > 9`, extension: Semver(), want: true},
+		{name: "ordering", expression: `semver(v) < semver("1.10.0") && "file9" < "file10"`, extension: NewLanguage(Semver(), Ordering()), parameter: parameter, want: true},
+	}, t)
+}
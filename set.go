@@ -0,0 +1,138 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sets contains functions that treat arrays as sets.
+// Elements are equal like for the in operator, numbers are compared by value.
+// The results keep the order of the first occurrence of their elements.
+//
+//	union(a, b, ...) returns the elements of all arrays
+//	intersect(a, b, ...) returns the elements of a that are in all other arrays
+//	difference(a, b, ...) returns the elements of a that are in none of the other arrays
+//	subsetOf(a, b) reports if all elements of a are in b
+//	unique(a) returns the distinct elements of a
+func Sets() Language {
+	return sets
+}
+
+var sets = NewLanguage(
+	Function("union", setFunction("union", 0, func(a []interface{}, others [][]interface{}) interface{} {
+		union := unique(a)
+		for _, o := range others {
+			for _, v := range o {
+				if !containsValue(union, v) {
+					union = append(union, v)
+				}
+			}
+		}
+		return union
+	}), setInfo("union returns the elements of all arrays.", `union(tags, ["default"])`, true, "arrays")),
+	Function("intersect", setFunction("intersect", 2, func(a []interface{}, others [][]interface{}) interface{} {
+		return filterSet(a, func(v interface{}) bool {
+			for _, o := range others {
+				if !containsValue(o, v) {
+					return false
+				}
+			}
+			return true
+		})
+	}), setInfo("intersect returns the elements of the first array that are in all other arrays.", `intersect(roles, ["admin", "owner"])`, true, "arrays")),
+	Function("difference", setFunction("difference", 2, func(a []interface{}, others [][]interface{}) interface{} {
+		return filterSet(a, func(v interface{}) bool {
+			for _, o := range others {
+				if containsValue(o, v) {
+					return false
+				}
+			}
+			return true
+		})
+	}), setInfo("difference returns the elements of the first array that are in none of the other arrays.", `difference(permissions, revoked)`, true, "arrays")),
+	Function("subsetOf", func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		if len(arguments) != 2 {
+			return nil, fmt.Errorf("subsetOf() expects 2 arguments but got %d", len(arguments))
+		}
+		arrays, err := setArguments("subsetOf", arguments)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range arrays[0] {
+			if !containsValue(arrays[1], v) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}, setInfo("subsetOf reports if all elements of a are in b.", `subsetOf(required, granted)`, false, "a", "b")),
+	Function("unique", func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		if len(arguments) != 1 {
+			return nil, fmt.Errorf("unique() expects 1 argument but got %d", len(arguments))
+		}
+		arrays, err := setArguments("unique", arguments)
+		if err != nil {
+			return nil, err
+		}
+		return unique(arrays[0]), nil
+	}, setInfo("unique returns the distinct elements of a.", `unique(tags)`, false, "a")),
+)
+
+func setInfo(description, example string, variadic bool, parameters ...string) FunctionInfo {
+	info := functionInfo(description, example, parameters...)
+	info.Variadic = variadic
+	return info
+}
+
+// setFunction returns a function of at least min arrays.
+func setFunction(name string, min int, f func(a []interface{}, others [][]interface{}) interface{}) func(context.Context, ...interface{}) (interface{}, error) {
+	return func(c context.Context, arguments ...interface{}) (interface{}, error) {
+		if len(arguments) < min {
+			return nil, fmt.Errorf("%s() expects at least %d arrays but got %d", name, min, len(arguments))
+		}
+		arrays, err := setArguments(name, arguments)
+		if err != nil {
+			return nil, err
+		}
+		if len(arrays) == 0 {
+			return []interface{}{}, nil
+		}
+		return f(arrays[0], arrays[1:]), nil
+	}
+}
+
+func setArguments(name string, arguments []interface{}) ([][]interface{}, error) {
+	arrays := make([][]interface{}, len(arguments))
+	for i, a := range arguments {
+		values, ok := arrayElements(a)
+		if !ok {
+			return nil, fmt.Errorf("%s() expects arrays but got %T", name, a)
+		}
+		arrays[i] = values
+	}
+	return arrays, nil
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if equalValues(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// unique returns the distinct values in order of their first occurrence.
+func unique(values []interface{}) []interface{} {
+	return filterSet(values, func(interface{}) bool { return true })
+}
+
+// filterSet returns the distinct values that match keep.
+func filterSet(values []interface{}, keep func(interface{}) bool) []interface{} {
+	result := []interface{}{}
+	for _, v := range values {
+		if !containsValue(result, v) && keep(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
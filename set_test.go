@@ -0,0 +1,59 @@
+package gval
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestInOperator(t *testing.T) {
+	parameter := map[string]interface{}{
+		"ints":    []int{1, 2, 3},
+		"array":   [2]string{"a", "b"},
+		"json":    []interface{}{int64(1), "x"},
+		"object":  map[string]interface{}{"k": 1},
+		"counts":  map[int]string{4: "four"},
+		"decimal": decimal.RequireFromString("2.5"),
+		"uint":    uint8(3),
+	}
+	testEvaluate([]evaluationTest{
+		{name: "typed slice", expression: `2 in ints`, parameter: parameter, want: true},
+		{name: "typed slice missing", expression: `4 in ints`, parameter: parameter, want: false},
+		{name: "array", expression: `"b" in array`, parameter: parameter, want: true},
+		{name: "int64 element", expression: `1 in json`, parameter: parameter, want: true},
+		{name: "uint in json array", expression: `uint in [1, 3.0]`, parameter: parameter, want: true},
+		{name: "decimal in json array", expression: `decimal in [2.5]`, parameter: parameter, want: true},
+		{name: "fraction is no int", expression: `1.5 in ints`, parameter: parameter, want: false},
+		{name: "map key", expression: `"k" in object`, parameter: parameter, want: true},
+		{name: "map value is no key", expression: `1 in object`, parameter: parameter, want: false},
+		{name: "typed map key", expression: `4 in counts`, parameter: parameter, want: true},
+		{name: "substring", expression: `"ell" in "hello"`, want: true},
+		{name: "no substring", expression: `"x" in "hello"`, want: false},
+		{name: "not in typed slice", expression: `5 not in ints`, extension: SQLOperators(), parameter: parameter, want: true},
+	}, t)
+}
+
+func TestSets(t *testing.T) {
+	parameter := map[string]interface{}{
+		"roles":   []string{"admin", "user"},
+		"granted": []interface{}{"user", "admin", "audit"},
+		"ids":     []int{1, 2, 2},
+	}
+	tests := []evaluationTest{
+		{name: "union", expression: `union([1, 2], [2, 3], [3, 4])`, want: []interface{}{1., 2., 3., 4.}},
+		{name: "union typed", expression: `union(ids, [3])`, parameter: parameter, want: []interface{}{1, 2, 3.}},
+		{name: "union without arguments", expression: `union()`, want: []interface{}{}},
+		{name: "intersect", expression: `intersect(roles, ["admin", "owner"])`, parameter: parameter, want: []interface{}{"admin"}},
+		{name: "intersect numbers", expression: `intersect(ids, [2.0, 5])`, parameter: parameter, want: []interface{}{2}},
+		{name: "intersect arity", expression: `intersect([1])`, wantErr: "intersect() expects at least 2 arrays but got 1"},
+		{name: "difference", expression: `difference([1, 2, 3, 1], [2], [3])`, want: []interface{}{1.}},
+		{name: "subsetOf", expression: `subsetOf(roles, granted)`, parameter: parameter, want: true},
+		{name: "no subsetOf", expression: `subsetOf(granted, roles)`, parameter: parameter, want: false},
+		{name: "unique", expression: `unique(ids)`, parameter: parameter, want: []interface{}{1, 2}},
+		{name: "no array", expression: `unique("abc")`, wantErr: "unique() expects arrays but got string"},
+	}
+	for i := range tests {
+		tests[i].extension = Sets()
+	}
+	testEvaluate(tests, t)
+}
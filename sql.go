@@ -0,0 +1,216 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/scanner"
+)
+
+// SQLOperators contains SQL style operators and keywords.
+// All keywords can be written in lower or upper case.
+//
+//	a like b is true iff string a matches pattern b.
+//	  % in b matches any sequence of characters, _ matches a single character.
+//	  \% and \_ match the literal characters.
+//	a ilike b is the case insensitive variant of like.
+//	a not like b, a not ilike b negate like and ilike.
+//	a between b and c is true iff b <= a <= c
+//	  with the <= operator of the Language. b and c are single operands.
+//	  Use parentheses for compound bounds.
+//	a not between b and c negates between.
+//	a is null is true iff a is nil.
+//	a is not null is true iff a is not nil.
+//	a not in b negates a in b.
+//	null is the nil constant.
+//	and, or, not are aliases for &&, || and !.
+func SQLOperators() Language {
+	return sqlOperators
+}
+
+var sqlOperators = func() Language {
+	var languages []Language
+	for _, upper := range []bool{false, true} {
+		name := func(s string) string {
+			if upper {
+				return strings.ToUpper(s)
+			}
+			return s
+		}
+		languages = append(languages,
+			InfixEvalOperator(name("like"), like(false, false)),
+			InfixEvalOperator(name("ilike"), like(true, false)),
+			InfixEvalOperator(name("not like"), like(false, true)),
+			InfixEvalOperator(name("not ilike"), like(true, true)),
+			InfixOperator(name("not in"), func(a, b interface{}) (interface{}, error) {
+				in, err := inArray(a, b)
+				if err != nil {
+					return nil, err
+				}
+				return in != true, nil
+			}),
+			PostfixOperator(name("between"), parseBetween(false)),
+			PostfixOperator(name("not between"), parseBetween(true)),
+			PostfixOperator(name("is null"), func(c context.Context, p *Parser, eval Evaluable) (Evaluable, error) {
+				return isNull(eval, true), nil
+			}),
+			PostfixOperator(name("is not null"), func(c context.Context, p *Parser, eval Evaluable) (Evaluable, error) {
+				return isNull(eval, false), nil
+			}),
+			Constant(name("null"), nil),
+
+			PrefixOperator(name("not"), func(c context.Context, v interface{}) (interface{}, error) {
+				b, ok := contextConversion(c).boolean(v)
+				if !ok {
+					return nil, fmt.Errorf("unexpected %T expected bool", v)
+				}
+				return !b, nil
+			}),
+			InfixShortCircuit(name("and"), func(a interface{}) (interface{}, bool) { return false, a == false }),
+			InfixBoolOperator(name("and"), func(a, b bool) (interface{}, error) { return a && b, nil }),
+			InfixShortCircuit(name("or"), func(a interface{}) (interface{}, bool) { return true, a == true }),
+			InfixBoolOperator(name("or"), func(a, b bool) (interface{}, error) { return a || b, nil }),
+
+			Precedence(name("or"), 20),
+			Precedence(name("and"), 21),
+		)
+		for _, op := range []string{"like", "ilike", "not like", "not ilike", "not in", "between", "not between", "is null", "is not null"} {
+			languages = append(languages, Precedence(name(op), 40))
+		}
+	}
+	return NewLanguage(languages...)
+}()
+
+func like(caseInsensitive, negate bool) func(a, b Evaluable) (Evaluable, error) {
+	match := func(regex *regexp.Regexp, s string) (interface{}, error) {
+		return regex.MatchString(s) != negate, nil
+	}
+	return func(a, b Evaluable) (Evaluable, error) {
+		if !b.IsConst() {
+			return func(c context.Context, v interface{}) (interface{}, error) {
+				s, err := a.EvalString(c, v)
+				if err != nil {
+					return nil, err
+				}
+				pattern, err := b.EvalString(c, v)
+				if err != nil {
+					return nil, err
+				}
+				regex, err := compileRegexp(c, likeRegexp(pattern, caseInsensitive))
+				if err != nil {
+					return nil, err
+				}
+				return match(regex, s)
+			}, nil
+		}
+		pattern, err := b.EvalString(context.TODO(), nil)
+		if err != nil {
+			return nil, err
+		}
+		regex, err := regexp.Compile(likeRegexp(pattern, caseInsensitive))
+		if err != nil {
+			return nil, err
+		}
+		size := lazyRegexpSize(regex.String())
+		return func(c context.Context, v interface{}) (interface{}, error) {
+			if err := checkRegexpSize(c, size); err != nil {
+				return nil, err
+			}
+			s, err := a.EvalString(c, v)
+			if err != nil {
+				return nil, err
+			}
+			return match(regex, s)
+		}, nil
+	}
+}
+
+// likeRegexp translates a like pattern into a regular expression.
+func likeRegexp(pattern string, caseInsensitive bool) string {
+	expr := strings.Builder{}
+	expr.WriteString("(?s")
+	if caseInsensitive {
+		expr.WriteString("i")
+	}
+	expr.WriteString(")^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(".*")
+		case r == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		expr.WriteString(regexp.QuoteMeta(`\`))
+	}
+	expr.WriteString("$")
+	return expr.String()
+}
+
+func parseBetween(negate bool) func(context.Context, *Parser, Evaluable) (Evaluable, error) {
+	return func(c context.Context, p *Parser, eval Evaluable) (Evaluable, error) {
+		lower, err := p.ParseNextExpression(c)
+		if err != nil {
+			return nil, err
+		}
+		if !p.scanKeyword("and") {
+			return nil, p.Expected("between", scanner.Ident)
+		}
+		upper, err := p.ParseNextExpression(c)
+		if err != nil {
+			return nil, err
+		}
+
+		lessEqual, ok := p.infixBuilder("<=")
+		if !ok {
+			return nil, fmt.Errorf("between requires operator <=")
+		}
+		subject := &binding{name: "between"}
+		aboveLower, err := lessEqual(lower, subject.get)
+		if err != nil {
+			return nil, err
+		}
+		belowUpper, err := lessEqual(subject.get, upper)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(c context.Context, v interface{}) (interface{}, error) {
+			x, err := eval(c, v)
+			if err != nil {
+				return nil, err
+			}
+			c = subject.bind(c, x)
+			for _, check := range []Evaluable{aboveLower, belowUpper} {
+				in, err := check.EvalBool(c, v)
+				if err != nil {
+					return nil, err
+				}
+				if !in {
+					return negate, nil
+				}
+			}
+			return !negate, nil
+		}, nil
+	}
+}
+
+func isNull(eval Evaluable, null bool) Evaluable {
+	return func(c context.Context, v interface{}) (interface{}, error) {
+		x, err := eval(c, v)
+		if err != nil {
+			return nil, err
+		}
+		return (x == nil) == null, nil
+	}
+}
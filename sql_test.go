@@ -0,0 +1,169 @@
+package gval
+
+import (
+	"testing"
+)
+
+func TestSQLOperators(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "like",
+				expression: `"gval.go" like "%.go"`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "like single character",
+				expression: `"gval" like "g_al"`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "like is anchored",
+				expression: `"gval.go.txt" like "%.go"`,
+				extension:  SQLOperators(),
+				want:       false,
+			},
+			{
+				name:       "like escaped wildcard",
+				expression: `"50%" like "50\\%" && !("500" like "50\\%")`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "like regex characters",
+				expression: `"a.b" like "a.b" && !("axb" like "a.b")`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "like is case sensitive",
+				expression: `"GVAL" like "gval"`,
+				extension:  SQLOperators(),
+				want:       false,
+			},
+			{
+				name:       "ilike",
+				expression: `"GVAL" ilike "g%"`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "like parameter pattern",
+				expression: `name like pattern`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{"name": "gval", "pattern": "%va%"},
+				want:       true,
+			},
+			{
+				name:       "not like",
+				expression: `"gval" not like "%x%"`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "NOT ILIKE",
+				expression: `"gval" NOT ILIKE "G%"`,
+				extension:  SQLOperators(),
+				want:       false,
+			},
+			{
+				name:       "between",
+				expression: `x between 1 and 10`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{"x": 10},
+				want:       true,
+			},
+			{
+				name:       "between with expression",
+				expression: `x * 2 between lower and (upper - 1) and true`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{"x": 5, "lower": 1, "upper": 10},
+				want:       false,
+			},
+			{
+				name:       "between strings",
+				expression: `"b" between "a" and "c"`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "not between",
+				expression: `-1 not between 0 and 1`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "BETWEEN",
+				expression: `2 BETWEEN 1 AND 3`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "between without and",
+				expression: `2 between 1 or 3`,
+				extension:  SQLOperators(),
+				wantErr:    unexpected("Ident", "between"),
+			},
+			{
+				name:       "is null",
+				expression: `missing is null`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{},
+				want:       true,
+			},
+			{
+				name:       "is not null",
+				expression: `x is not null and x > 1`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{"x": 2},
+				want:       true,
+			},
+			{
+				name:       "IS NULL",
+				expression: `NULL IS NULL`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "not in",
+				expression: `3 not in [1, 2]`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "not in with member",
+				expression: `2 not in [1, 2]`,
+				extension:  SQLOperators(),
+				want:       false,
+			},
+			{
+				name:       "and or not",
+				expression: `not false and (false or true)`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "or has lower precedence than and",
+				expression: `true or false and false`,
+				extension:  SQLOperators(),
+				want:       true,
+			},
+			{
+				name:       "and short circuit",
+				expression: `false and foo.AlwaysFail()`,
+				extension:  SQLOperators(),
+				parameter:  map[string]interface{}{"foo": foo},
+				want:       false,
+			},
+			{
+				name:       "unknown multi word operator",
+				expression: `1 is 2`,
+				extension:  SQLOperators(),
+				wantErr:    "unknown operator is 2",
+			},
+		},
+		t,
+	)
+}
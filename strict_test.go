@@ -0,0 +1,113 @@
+package gval
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+type strictString string
+
+func TestStrict(t *testing.T) {
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "string number comparison",
+				expression: `"10" > 9`,
+				extension:  Strict(),
+				wantErr:    "invalid operation (string) > (float64)",
+			},
+			{
+				name:       "string number comparison without Strict",
+				expression: `"10" > 9`,
+				want:       true,
+			},
+			{
+				name:       "string and number",
+				expression: `"true" && 1`,
+				extension:  Strict(),
+				wantErr:    "invalid operation (string) && (float64)",
+			},
+			{
+				name:       "number plus string",
+				expression: `1 + "a"`,
+				extension:  Strict(),
+				wantErr:    "invalid operation (float64) + (string)",
+			},
+			{
+				name:       "int parameter",
+				expression: `x + 1`,
+				extension:  Strict(),
+				parameter:  map[string]interface{}{"x": int32(2)},
+				want:       3.,
+			},
+			{
+				name:       "named string parameter",
+				expression: `x + "b"`,
+				extension:  Strict(),
+				parameter:  map[string]interface{}{"x": strictString("a")},
+				want:       "ab",
+			},
+			{
+				name:       "bool pointer parameter",
+				expression: `x && true`,
+				extension:  Strict(),
+				parameter:  map[string]interface{}{"x": &[]bool{true}[0]},
+				want:       true,
+			},
+			{
+				name:       "equality of different types",
+				expression: `"1" == 1`,
+				extension:  Strict(),
+				wantErr:    "invalid operation (string) == (float64)",
+			},
+			{
+				name:       "inequality of different types",
+				expression: `true != 1`,
+				extension:  Strict(),
+				wantErr:    "invalid operation (bool) != (float64)",
+			},
+			{
+				name:       "equality with nil and arrays",
+				expression: `x == 2 && [1] == [1] && nil != x && "a" != "b"`,
+				extension:  Strict(),
+				parameter:  map[string]interface{}{"x": int32(2)},
+				want:       true,
+			},
+			{
+				name:       "negation of a string",
+				expression: `-"1"`,
+				extension:  Strict(),
+				wantErr:    `unexpected 1(string) expected number`,
+			},
+			{
+				name:       "negation of a number parameter",
+				expression: `-x`,
+				extension:  Strict(),
+				parameter:  map[string]interface{}{"x": int32(2)},
+				want:       -2.,
+			},
+			{
+				name:       "not of a number",
+				expression: `!1`,
+				extension:  Strict(),
+				wantErr:    "unexpected float64 expected bool",
+			},
+			{
+				name:         "decimal",
+				expression:   `x * 2`,
+				extension:    NewLanguage(DecimalArithmetic(), Strict()),
+				parameter:    map[string]interface{}{"x": 3},
+				want:         decimal.NewFromInt(6),
+				equalityFunc: decimalEqualityFunc,
+			},
+			{
+				name:       "decimal string",
+				expression: `"3" * 2`,
+				extension:  NewLanguage(DecimalArithmetic(), Strict()),
+				wantErr:    "invalid operation (string) * (decimal.Decimal)",
+			},
+		},
+		t,
+	)
+}
@@ -0,0 +1,207 @@
+package gval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Strings contains functions for strings. Positions and lengths count characters (runes), not bytes.
+//
+//	len(s), upper(s), lower(s)
+//	trim(s), trim(s, cutset), trimLeft(s, cutset), trimRight(s, cutset), trimPrefix(s, prefix), trimSuffix(s, suffix)
+//	startsWith(s, prefix), endsWith(s, suffix), contains(s, substr), indexOf(s, substr)
+//	substr(s, start), substr(s, start, length)
+//	replace(s, old, new), split(s, sep), join(array, sep), repeat(s, count)
+//	padLeft(s, width), padLeft(s, width, pad), padRight(s, width), padRight(s, width, pad)
+//	format(format, args...) formats like fmt.Sprintf, integral numbers also with %d, %x, %o, %b and %c
+//	normalize(s, form) with form NFC, NFD, NFKC or NFKD
+//	fold(s) and equalFold(a, b) for case insensitive comparisons
+func Strings() Language {
+	return stringFunctions
+}
+
+var stringFunctions = NewLanguage(
+	Function("len", utf8.RuneCountInString,
+		functionInfo("len returns the number of characters of s.", `len("äbc")`, "s")),
+	Function("upper", strings.ToUpper,
+		functionInfo("upper returns s in upper case.", `upper("abc")`, "s")),
+	Function("lower", strings.ToLower,
+		functionInfo("lower returns s in lower case.", `lower("ABC")`, "s")),
+
+	FunctionOverload("trim", strings.TrimSpace,
+		functionInfo("trim removes leading and trailing white space.", `trim(" abc ")`, "s")),
+	FunctionOverload("trim", strings.Trim,
+		functionInfo("trim removes leading and trailing characters contained in cutset.", `trim("xabcx", "x")`, "s", "cutset")),
+	Function("trimLeft", strings.TrimLeft,
+		functionInfo("trimLeft removes leading characters contained in cutset.", `trimLeft("xabc", "x")`, "s", "cutset")),
+	Function("trimRight", strings.TrimRight,
+		functionInfo("trimRight removes trailing characters contained in cutset.", `trimRight("abcx", "x")`, "s", "cutset")),
+	Function("trimPrefix", strings.TrimPrefix,
+		functionInfo("trimPrefix removes prefix from s.", `trimPrefix("abc", "ab")`, "s", "prefix")),
+	Function("trimSuffix", strings.TrimSuffix,
+		functionInfo("trimSuffix removes suffix from s.", `trimSuffix("abc", "bc")`, "s", "suffix")),
+
+	Function("startsWith", strings.HasPrefix,
+		functionInfo("startsWith reports whether s begins with prefix.", `startsWith("abc", "ab")`, "s", "prefix")),
+	Function("endsWith", strings.HasSuffix,
+		functionInfo("endsWith reports whether s ends with suffix.", `endsWith("abc", "bc")`, "s", "suffix")),
+	Function("contains", strings.Contains,
+		functionInfo("contains reports whether substr is within s.", `contains("abc", "b")`, "s", "substr")),
+	Function("indexOf", func(s, substr string) int {
+		i := strings.Index(s, substr)
+		if i < 0 {
+			return i
+		}
+		return utf8.RuneCountInString(s[:i])
+	}, functionInfo("indexOf returns the position of the first substr in s or -1.", `indexOf("äbc", "c")`, "s", "substr")),
+
+	FunctionOverload("substr", func(s string, start int) (string, error) {
+		return substr(s, start, -1)
+	}, functionInfo("substr returns the characters of s from start.", `substr("abc", 1)`, "s", "start")),
+	FunctionOverload("substr", substr,
+		functionInfo("substr returns at most length characters of s from start.", `substr("abc", 1, 1)`, "s", "start", "length")),
+
+	Function("replace", func(s, old, new string) string {
+		return strings.ReplaceAll(s, old, new)
+	}, functionInfo("replace replaces all old in s by new.", `replace("a-b-c", "-", "+")`, "s", "old", "new")),
+	Function("split", func(s, sep string) []interface{} {
+		return stringsToInterfaces(strings.Split(s, sep))
+	}, functionInfo("split splits s around sep.", `split("a,b,c", ",")`, "s", "sep")),
+	Function("join", strings.Join,
+		functionInfo("join concatenates the strings of array with sep.", `join(["a", "b"], ",")`, "array", "sep")),
+	Function("repeat", func(c context.Context, s string, count int) (string, error) {
+		if count < 0 {
+			return "", fmt.Errorf("repeat() expects a count >= 0 but got %d", count)
+		}
+		if err := checkStringLength(c, len(s)*count); err != nil {
+			return "", err
+		}
+		return strings.Repeat(s, count), nil
+	}, functionInfo("repeat returns count copies of s.", `repeat("ab", 3)`, "s", "count")),
+
+	FunctionOverload("padLeft", func(c context.Context, s string, width int) (string, error) {
+		return pad(c, s, width, " ", true)
+	}, functionInfo("padLeft prepends spaces to s up to width characters.", `padLeft("7", 3)`, "s", "width")),
+	FunctionOverload("padLeft", func(c context.Context, s string, width int, p string) (string, error) {
+		return pad(c, s, width, p, true)
+	}, functionInfo("padLeft prepends pad to s up to width characters.", `padLeft("7", 3, "0")`, "s", "width", "pad")),
+	FunctionOverload("padRight", func(c context.Context, s string, width int) (string, error) {
+		return pad(c, s, width, " ", false)
+	}, functionInfo("padRight appends spaces to s up to width characters.", `padRight("7", 3)`, "s", "width")),
+	FunctionOverload("padRight", func(c context.Context, s string, width int, p string) (string, error) {
+		return pad(c, s, width, p, false)
+	}, functionInfo("padRight appends pad to s up to width characters.", `padRight("7", 3, ".")`, "s", "width", "pad")),
+
+	Function("format", func(format string, args ...interface{}) string {
+		return fmt.Sprintf(format, formatArgs(format, args)...)
+	}, functionInfo("format formats args like fmt.Sprintf.", `format("%s: %.2f", "total", 2.5)`, "format", "args")),
+
+	Function("normalize", func(s, form string) (string, error) {
+		switch strings.ToUpper(form) {
+		case "NFC":
+			return norm.NFC.String(s), nil
+		case "NFD":
+			return norm.NFD.String(s), nil
+		case "NFKC":
+			return norm.NFKC.String(s), nil
+		case "NFKD":
+			return norm.NFKD.String(s), nil
+		}
+		return "", fmt.Errorf("normalize() expects form NFC, NFD, NFKC or NFKD but got %s", form)
+	}, functionInfo("normalize returns s in the unicode normalization form NFC, NFD, NFKC or NFKD.", `normalize("é", "NFC")`, "s", "form")),
+	Function("fold", func(s string) string {
+		return cases.Fold().String(s)
+	}, functionInfo("fold returns the case folded s for case insensitive comparisons.", `fold("Straße") == fold("STRASSE")`, "s")),
+	Function("equalFold", strings.EqualFold,
+		functionInfo("equalFold reports whether a and b are equal under simple case folding.", `equalFold("Go", "GO")`, "a", "b")),
+)
+
+// substr returns at most length runes of s from start. A negative length returns all runes from start.
+func substr(s string, start, length int) (string, error) {
+	if start < 0 {
+		return "", fmt.Errorf("substr() expects a start >= 0 but got %d", start)
+	}
+	runes := []rune(s)
+	if start > len(runes) {
+		start = len(runes)
+	}
+	end := len(runes)
+	if length >= 0 && start+length < end {
+		end = start + length
+	}
+	return string(runes[start:end]), nil
+}
+
+func pad(c context.Context, s string, width int, p string, left bool) (string, error) {
+	missing := width - utf8.RuneCountInString(s)
+	if missing <= 0 || p == "" {
+		return s, nil
+	}
+	runes := []rune(p)
+	count := missing / len(runes)
+	rest := string(runes[:missing%len(runes)])
+	if err := checkStringLength(c, len(s)+count*len(p)+len(rest)); err != nil {
+		return "", err
+	}
+	padding := strings.Repeat(p, count) + rest
+	if left {
+		return padding + s, nil
+	}
+	return s + padding, nil
+}
+
+// formatArgs converts integral float64 arguments of the integer verbs %d, %x, %X, %o, %O, %b and %c
+// and of * widths and precisions to int64 because numbers in expressions are float64.
+func formatArgs(format string, args []interface{}) []interface{} {
+	converted := append([]interface{}(nil), args...)
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+	verb:
+		for i++; i < len(format); i++ {
+			switch c := format[i]; {
+			case c == '*':
+				formatInt(converted, arg)
+				arg++
+			case c == '[':
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					return converted
+				}
+				if n, err := strconv.Atoi(format[i+1 : i+end]); err == nil {
+					arg = n - 1
+				}
+				i += end
+			case strings.IndexByte("+-# 0123456789.", c) >= 0:
+			case c == '%':
+				break verb
+			default:
+				if strings.IndexByte("dxXoObc", c) >= 0 {
+					formatInt(converted, arg)
+				}
+				arg++
+				break verb
+			}
+		}
+	}
+	return converted
+}
+
+// formatInt converts args[i] to int64 if it is an integral float64.
+func formatInt(args []interface{}, i int) {
+	if i < 0 || i >= len(args) {
+		return
+	}
+	if f, ok := args[i].(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+		args[i] = int64(f)
+	}
+}
@@ -0,0 +1,303 @@
+package gval
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrings(t *testing.T) {
+	lang := Strings()
+	testEvaluate(
+		[]evaluationTest{
+			{
+				name:       "len",
+				expression: `len("äbc")`,
+				extension:  lang,
+				want:       3,
+			},
+			{
+				name:       "upper",
+				expression: `upper("abc")`,
+				extension:  lang,
+				want:       "ABC",
+			},
+			{
+				name:       "lower",
+				expression: `lower("ÄBC")`,
+				extension:  lang,
+				want:       "äbc",
+			},
+			{
+				name:       "trim space",
+				expression: `trim(" \tabc\n")`,
+				extension:  lang,
+				want:       "abc",
+			},
+			{
+				name:       "trim cutset",
+				expression: `trim("xyabcyx", "xy")`,
+				extension:  lang,
+				want:       "abc",
+			},
+			{
+				name:       "trimLeft",
+				expression: `trimLeft("xxabcx", "x")`,
+				extension:  lang,
+				want:       "abcx",
+			},
+			{
+				name:       "trimRight",
+				expression: `trimRight("xabcxx", "x")`,
+				extension:  lang,
+				want:       "xabc",
+			},
+			{
+				name:       "trimPrefix",
+				expression: `trimPrefix("abcab", "ab")`,
+				extension:  lang,
+				want:       "cab",
+			},
+			{
+				name:       "trimSuffix",
+				expression: `trimSuffix("abcab", "ab")`,
+				extension:  lang,
+				want:       "abc",
+			},
+			{
+				name:       "startsWith",
+				expression: `startsWith("abc", "ab")`,
+				extension:  lang,
+				want:       true,
+			},
+			{
+				name:       "not startsWith",
+				expression: `startsWith("abc", "bc")`,
+				extension:  lang,
+				want:       false,
+			},
+			{
+				name:       "endsWith",
+				expression: `endsWith("abc", "bc")`,
+				extension:  lang,
+				want:       true,
+			},
+			{
+				name:       "contains",
+				expression: `contains("abc", "b")`,
+				extension:  lang,
+				want:       true,
+			},
+			{
+				name:       "indexOf",
+				expression: `indexOf("äbc", "c")`,
+				extension:  lang,
+				want:       2,
+			},
+			{
+				name:       "indexOf missing",
+				expression: `indexOf("abc", "d")`,
+				extension:  lang,
+				want:       -1,
+			},
+			{
+				name:       "substr",
+				expression: `substr("äbcd", 1)`,
+				extension:  lang,
+				want:       "bcd",
+			},
+			{
+				name:       "substr with length",
+				expression: `substr("äbcd", 0, 2)`,
+				extension:  lang,
+				want:       "äb",
+			},
+			{
+				name:       "substr beyond end",
+				expression: `substr("abc", 2, 5) + substr("abc", 5)`,
+				extension:  lang,
+				want:       "c",
+			},
+			{
+				name:       "substr negative start",
+				expression: `substr("abc", -1)`,
+				extension:  lang,
+				wantErr:    "substr() expects a start >= 0 but got -1",
+			},
+			{
+				name:       "substr fractional start",
+				expression: `substr("abc", 1.5)`,
+				extension:  lang,
+				wantErr:    "no matching overload for substr(string, float64)",
+			},
+			{
+				name:       "replace",
+				expression: `replace("a-b-c", "-", "+")`,
+				extension:  lang,
+				want:       "a+b+c",
+			},
+			{
+				name:       "split",
+				expression: `split("a,b,c", ",")`,
+				extension:  lang,
+				want:       []interface{}{"a", "b", "c"},
+			},
+			{
+				name:       "join",
+				expression: `join(["a", "b", "c"], "-")`,
+				extension:  lang,
+				want:       "a-b-c",
+			},
+			{
+				name:       "join with numbers",
+				expression: `join([1, 2], "-")`,
+				extension:  lang,
+				wantErr:    "expected type []string for parameter 0 but got []interface {}",
+			},
+			{
+				name:       "repeat",
+				expression: `repeat("ab", 3)`,
+				extension:  lang,
+				want:       "ababab",
+			},
+			{
+				name:       "repeat negative",
+				expression: `repeat("ab", -1)`,
+				extension:  lang,
+				wantErr:    "repeat() expects a count >= 0 but got -1",
+			},
+			{
+				name:       "padLeft",
+				expression: `padLeft("7", 3)`,
+				extension:  lang,
+				want:       "  7",
+			},
+			{
+				name:       "padLeft with pad",
+				expression: `padLeft("7", 4, "0")`,
+				extension:  lang,
+				want:       "0007",
+			},
+			{
+				name:       "padLeft with long pad",
+				expression: `padLeft("7", 4, "äb")`,
+				extension:  lang,
+				want:       "äbä7",
+			},
+			{
+				name:       "padLeft wide enough",
+				expression: `padLeft("1234", 3, "0")`,
+				extension:  lang,
+				want:       "1234",
+			},
+			{
+				name:       "padRight",
+				expression: `padRight("7", 3)`,
+				extension:  lang,
+				want:       "7  ",
+			},
+			{
+				name:       "padRight with pad",
+				expression: `padRight("7", 3, ".")`,
+				extension:  lang,
+				want:       "7..",
+			},
+			{
+				name:       "format",
+				expression: `format("%s: %.2f", "total", 2.5)`,
+				extension:  lang,
+				want:       "total: 2.50",
+			},
+			{
+				name:       "format without args",
+				expression: `format("100%%")`,
+				extension:  lang,
+				want:       "100%",
+			},
+			{
+				name:       "format integer verb",
+				expression: `format("%s-%d", "a", 3)`,
+				extension:  lang,
+				want:       "a-3",
+			},
+			{
+				name:       "format integer verbs",
+				expression: `format("%x %c %05.1f %*d %[1]o", 255, 65, 2, 3, -4)`,
+				extension:  lang,
+				want:       "ff A 002.0  -4 377",
+			},
+			{
+				name:       "format fraction with integer verb",
+				expression: `format("%d", 1.5)`,
+				extension:  lang,
+				want:       "%!d(float64=1.5)",
+			},
+			{
+				name:       "normalize NFC",
+				expression: `normalize("e\u0301", "NFC")`,
+				extension:  lang,
+				want:       "\u00e9",
+			},
+			{
+				name:       "normalize NFD",
+				expression: `normalize("\u00e9", "nfd")`,
+				extension:  lang,
+				want:       "e\u0301",
+			},
+			{
+				name:       "normalize NFKC",
+				expression: `normalize("ﬁ", "NFKC")`,
+				extension:  lang,
+				want:       "fi",
+			},
+			{
+				name:       "normalize NFKD",
+				expression: `normalize("ﬁ", "NFKD")`,
+				extension:  lang,
+				want:       "fi",
+			},
+			{
+				name:       "normalize unknown form",
+				expression: `normalize("a", "X")`,
+				extension:  lang,
+				wantErr:    "normalize() expects form NFC, NFD, NFKC or NFKD but got X",
+			},
+			{
+				name:       "fold",
+				expression: `fold("Straße") == fold("STRASSE")`,
+				extension:  lang,
+				want:       true,
+			},
+			{
+				name:       "equalFold",
+				expression: `equalFold("Go", "GO")`,
+				extension:  lang,
+				want:       true,
+			},
+			{
+				name:       "parameter",
+				expression: `upper(s) + lower(s)`,
+				extension:  lang,
+				parameter:  map[string]interface{}{"s": "Ab"},
+				want:       "ABab",
+			},
+			{
+				name:       "with regex",
+				expression: `replace("a1", "1", "2") + regexReplace("a1", "[0-9]", "3")`,
+				extension:  NewLanguage(lang, Regex()),
+				want:       "a2a3",
+			},
+		},
+		t,
+	)
+}
+
+func TestStrings_budget(t *testing.T) {
+	lang := NewLanguage(Full(), Strings(), EvaluationBudget(Budget{MaxStringLength: 10}))
+	for _, expression := range []string{`repeat(s, 100)`, `padLeft(s, 100, "0")`} {
+		_, err := lang.Evaluate(expression, map[string]interface{}{"s": "ab"})
+		var exceeded *BudgetExceeded
+		if !errors.As(err, &exceeded) {
+			t.Errorf("Evaluate(%s) error = %v, want BudgetExceeded", expression, err)
+		}
+	}
+}